@@ -26,8 +26,7 @@ var (
 	style5          = lipgloss.NewStyle().Align(lipgloss.Center).Background(lipgloss.Color("#10ac84"))
 	style6          = lipgloss.NewStyle().Align(lipgloss.Center).Background(lipgloss.Color("#222f3e"))
 
-	tableRowIndex  = 1
-	tableCellIndex = 1
+	tableRowIndex = 1
 )
 
 // Model is the Bubble Tea model for demo 3
@@ -94,7 +93,7 @@ func New() *Model {
 		headers: headers,
 	}
 
-	m.table.SetRatio(ratio).SetMinWidth(minSize)
+	m.table.MustSetRatio(ratio).MustSetMinWidth(minSize)
 	if _, err := m.table.AddRows(rows); err != nil {
 		panic(err)
 	}
@@ -107,7 +106,7 @@ func New() *Model {
 	).SetStyle(styleRow)
 	r2 := m.flexBox.NewRow().AddCells(
 		flexbox.NewCell(1, 5).SetStyle(style6),
-		flexbox.NewCell(10, 5).SetStyle(styleBlank),
+		flexbox.NewCell(10, 5).SetStyle(styleBlank).SetRenderable(m.table),
 		flexbox.NewCell(1, 5).SetStyle(style6),
 	).SetStyle(styleRow)
 	r3 := m.flexBox.NewRow().AddCells(
@@ -147,8 +146,6 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = windowHeight
 		m.flexBox.SetWidth(windowWidth)
 		m.flexBox.SetHeight(windowHeight)
-		m.table.SetWidth(windowWidth)
-		m.table.SetHeight(windowHeight)
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -178,7 +175,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// add content to random boxes on flex box
 			for ir := 0; ir < m.flexBox.RowsLen(); ir++ {
 				// don't' want it on the middle row
-				if ir == 1 {
+				if ir == tableRowIndex {
 					continue
 				}
 				// not handling error for example script
@@ -237,19 +234,7 @@ var aboutStyle = lipgloss.NewStyle().
 
 // View implements tea.Model
 func (m *Model) View() string {
-	m.flexBox.ForceRecalculate()
-	_r := m.flexBox.GetRow(tableRowIndex)
-	if _r == nil {
-		panic("could not find the table row")
-	}
-	_c := _r.GetCell(tableCellIndex)
-	if _c == nil {
-		panic("could not find the table cell")
-	}
-	m.table.SetWidth(_c.GetWidth())
-	m.table.SetHeight(_c.GetHeight())
-	_c.SetContent(m.table.Render())
-
+	// table is nested in the flexbox and sized by its cell
 	content := m.flexBox.Render()
 	if m.showAbout {
 		overlay := aboutStyle.Render(aboutText)
@@ -80,7 +80,7 @@ func New() *Model {
 	}
 	m.table.SetStylePassing(true)
 	// setup
-	m.table.SetRatio(ratio).SetMinWidth(minSize)
+	m.table.MustSetRatio(ratio).MustSetMinWidth(minSize)
 	// add rows
 	if _, err := m.table.AddRows(rows); err != nil {
 		panic(err)
@@ -37,8 +37,16 @@ numerically (1, 2, 10) instead of lexically (1, 10, 2).
 Navigation:
 - Arrow keys: Move cursor
 - Ctrl+S: Sort by column (numeric or alpha)
+- Ctrl+F: Pick values of the column to filter by,
+  space toggles a value, type to search, esc closes
+- Ctrl+T: Show statistics of a numeric column
+- Ctrl+P: Toggle pages, PgUp/PgDown switch them
+- Ctrl+Z/Ctrl+Y: Undo/redo sorting and filtering
 - Enter/Space: Select cell value
 - Type to filter
+- Mouse: click a cell to select it, click a header
+  to sort, drag a header border to resize, wheel
+  to scroll
 
 Press 'a' to close | 'q' to quit`
 
@@ -91,7 +99,7 @@ func New() *Model {
 		panic(err)
 	}
 	// setup dimensions
-	m.table.SetRatio(ratio).SetMinWidth(minSize)
+	m.table.MustSetRatio(ratio).MustSetMinWidth(minSize)
 	// set style passing
 	m.table.SetStylePassing(true)
 	// add rows
@@ -108,6 +116,10 @@ func New() *Model {
 	infoText := `
 use the arrows to navigate
 ctrl+s: sort by current column
+ctrl+f: filter by column values
+ctrl+t: column statistics
+ctrl+p: pages, pgup/pgdown to switch
+ctrl+z, ctrl+y: undo, redo
 alphanumerics: filter column
 enter, spacebar: get column value
 ctrl+c: quit
@@ -130,7 +142,7 @@ ctrl+c: quit
 // Run starts the demo as a standalone program
 func Run() {
 	m := New()
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if err := p.Start(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
@@ -149,7 +161,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.table.SetWidth(msg.Width)
 		m.table.SetHeight(msg.Height - m.infoBox.GetHeight())
 		m.infoBox.SetWidth(msg.Width)
+	case tea.MouseMsg:
+		m.table.Update(msg)
 	case tea.KeyMsg:
+		// facet panel takes over the keys while it is open
+		if m.table.IsFacetPanelOpen() {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.table.Update(msg)
+			return m, nil
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
@@ -173,6 +195,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case table.SortingOrderDescending:
 				m.table.OrderByAsc(x)
 			}
+		case "ctrl+p":
+			m.table.SetPagination(!m.table.IsPaginated())
+		case "pgup", "pgdown", "ctrl+z", "ctrl+y":
+			m.table.Update(msg)
+		case "ctrl+t":
+			m.table.ToggleStatsPanel()
+		case "ctrl+f":
+			x, _ := m.table.GetCursorLocation()
+			m.table.OpenFacetPanel(x)
 		case "enter", " ":
 			selectedValue = m.table.GetCursorValue()
 			m.infoBox.GetRow(0).GetCell(1).SetContent("\nselected cell: " + selectedValue)
@@ -0,0 +1,113 @@
+package flexbox
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Justify places the cells along the line when they do not fill it, as justify-content in CSS
+type Justify int
+
+const (
+	// JustifyStart packs the cells at the start of the line, this is the default
+	JustifyStart Justify = iota
+	// JustifyEnd packs the cells at the end of the line
+	JustifyEnd
+	// JustifyCenter packs the cells in the middle of the line
+	JustifyCenter
+	// JustifySpaceBetween spreads the free space between the cells
+	JustifySpaceBetween
+	// JustifySpaceAround gives every cell the same space on both of its sides
+	JustifySpaceAround
+	// JustifySpaceEvenly makes the space before, between and after the cells equal
+	JustifySpaceEvenly
+)
+
+// Align places the cell across the line when it is smaller than the line, as align-items in CSS
+type Align int
+
+const (
+	// AlignAuto inherits the alignment of the line, on the line itself it means AlignStart
+	AlignAuto Align = iota
+	// AlignStart places the cell at the top of a row or the left of a column, this is the default
+	AlignStart
+	// AlignCenter places the cell in the middle of the line
+	AlignCenter
+	// AlignEnd places the cell at the bottom of a row or the right of a column
+	AlignEnd
+	// AlignStretch stretches the cell across the whole line, up to its max size
+	AlignStretch
+)
+
+// position returns the lipgloss position of the alignment
+func (a Align) position() lipgloss.Position {
+	switch a {
+	case AlignCenter:
+		return lipgloss.Center
+	case AlignEnd:
+		return lipgloss.Bottom
+	default:
+		return lipgloss.Top
+	}
+}
+
+// SetJustify sets how the cells are placed along the line when they do not fill it
+func (r *Line) SetJustify(justify Justify) *Line {
+	r.justify = justify
+	return r
+}
+
+// SetAlignItems sets how the cells are placed across the line, cells can override it with SetAlignSelf
+func (r *Line) SetAlignItems(align Align) *Line {
+	r.alignItems = align
+	r.setRecalculate()
+	return r
+}
+
+// SetAlignSelf sets how the cell is placed across its line, AlignAuto inherits the alignment of the line
+func (r *Cell) SetAlignSelf(align Align) *Cell {
+	r.alignSelf = align
+	return r
+}
+
+// cellAlign returns the alignment of the cell within the line
+func (r *Line) cellAlign(cell *Cell) Align {
+	if cell.alignSelf != AlignAuto {
+		return cell.alignSelf
+	}
+	return r.alignItems
+}
+
+// justifySpacing splits the free space of the line into the spaces before, between and after
+// the items, the result has one more entry than there are items
+func justifySpacing(justify Justify, free, items int) []int {
+	weights := make([]int, items+1)
+	if free <= 0 || items == 0 {
+		return weights
+	}
+	switch justify {
+	case JustifyEnd:
+		weights[0] = 1
+	case JustifyCenter:
+		weights[0], weights[items] = 1, 1
+	case JustifySpaceBetween:
+		if items == 1 {
+			// single item is packed at the start as in CSS
+			weights[items] = 1
+		}
+		for i := 1; i < items; i++ {
+			weights[i] = 1
+		}
+	case JustifySpaceAround:
+		weights[0], weights[items] = 1, 1
+		for i := 1; i < items; i++ {
+			weights[i] = 2
+		}
+	case JustifySpaceEvenly:
+		for i := range weights {
+			weights[i] = 1
+		}
+	default:
+		weights[items] = 1
+	}
+	return shareByWeight(free, weights)
+}
@@ -0,0 +1,34 @@
+package flexbox
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJustifySpacing(t *testing.T) {
+	tests := []struct {
+		name    string
+		justify Justify
+		free    int
+		items   int
+		want    []int
+	}{
+		{"start", JustifyStart, 6, 2, []int{0, 0, 6}},
+		{"end", JustifyEnd, 6, 2, []int{6, 0, 0}},
+		{"center", JustifyCenter, 6, 2, []int{3, 0, 3}},
+		{"center odd", JustifyCenter, 5, 2, []int{3, 0, 2}},
+		{"space between", JustifySpaceBetween, 6, 3, []int{0, 3, 3, 0}},
+		{"space between single item", JustifySpaceBetween, 6, 1, []int{0, 6}},
+		{"space around", JustifySpaceAround, 8, 2, []int{2, 4, 2}},
+		{"space evenly", JustifySpaceEvenly, 6, 2, []int{2, 2, 2}},
+		{"no free space", JustifySpaceEvenly, 0, 2, []int{0, 0, 0}},
+		{"no items", JustifyCenter, 6, 0, []int{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := justifySpacing(tt.justify, tt.free, tt.items); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("justifySpacing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package flexbox
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Direction is the axis the cells of a line are laid along, lines are stacked along the other axis
+type Direction int
+
+const (
+	// DirectionRow lays the cells of a line horizontally and stacks the lines vertically, as FlexBox does
+	DirectionRow Direction = iota
+	// DirectionColumn lays the cells of a line vertically and stacks the lines horizontally, as HorizontalFlexBox does
+	DirectionColumn
+)
+
+// split returns the size along the direction (main) and across it (cross)
+func (d Direction) split(width, height int) (main, cross int) {
+	if d == DirectionColumn {
+		return height, width
+	}
+	return width, height
+}
+
+// join is the inverse of split, returns width and height from the main and cross size
+func (d Direction) join(main, cross int) (width, height int) {
+	if d == DirectionColumn {
+		return cross, main
+	}
+	return main, cross
+}
+
+// Box responsive box grid inspired by CSS flexbox, it holds lines of cells laid along its direction,
+// every sizing feature works on both axes. Boxes are nested by holding them in a cell with SetRenderable
+type Box struct {
+	// direction of the lines, see Direction
+	direction Direction
+
+	// style to apply to the box itself
+	style         lipgloss.Style
+	styleAncestor bool
+
+	// width is fixed width of the box
+	width int
+	// height is fixed height of the box
+	height int
+	// fixedLineSize will lock the cross size of the lines to a number, this disables responsiveness
+	fixedLineSize int
+
+	lines []*Line
+
+	// recalculateFlag indicates if next render should make calculations regarding
+	// the lines objects size
+	recalculateFlag bool
+
+	// lineAlign controls the alignment across the lines when joining lines of varying size
+	lineAlign lipgloss.Position
+
+	// rowGap and columnGap space between the rows and the columns, see SetGap
+	rowGap    int
+	columnGap int
+	// rowGutter and columnGutter fill the gaps, see SetGutter
+	rowGutter    string
+	columnGutter string
+	gutterStyle  lipgloss.Style
+
+	// layouts applied by the width of the box and the min width of the applied one, see Responsive
+	layouts      map[int]LayoutFunc
+	activeLayout int
+
+	// rendered is set by the first render, the placement of the cells is known from then on
+	rendered bool
+}
+
+// NewBox initialize Box object with defaults
+func NewBox(direction Direction, width, height int) *Box {
+	return &Box{
+		direction:     direction,
+		width:         width,
+		height:        height,
+		fixedLineSize: -1,
+		style:         lipgloss.NewStyle(),
+		lineAlign:     lipgloss.Left,
+		gutterStyle:   lipgloss.NewStyle(),
+		activeLayout:  -1,
+	}
+}
+
+// SetDirection sets the direction of the lines
+func (r *Box) SetDirection(direction Direction) *Box {
+	r.direction = direction
+	r.setRecalculate()
+	return r
+}
+
+// GetDirection returns the direction of the lines
+func (r *Box) GetDirection() Direction {
+	return r.direction
+}
+
+// SetStyle replaces the style, it unsets width/height related keys
+func (r *Box) SetStyle(style lipgloss.Style) *Box {
+	r.style = style.
+		UnsetWidth().
+		UnsetMaxWidth().
+		UnsetHeight().
+		UnsetMaxHeight()
+	r.setRecalculate()
+	return r
+}
+
+// GetStyle returns the copy of the box current style
+func (r *Box) GetStyle() lipgloss.Style {
+	return r.style
+}
+
+// StylePassing set whether the style should be passed to the lines
+func (r *Box) StylePassing(value bool) *Box {
+	r.styleAncestor = value
+	return r
+}
+
+// SetLineAlign sets the alignment used when joining lines of varying size, lipgloss.Left, lipgloss.Center
+// or lipgloss.Right for rows and lipgloss.Top, lipgloss.Center or lipgloss.Bottom for columns
+func (r *Box) SetLineAlign(align lipgloss.Position) *Box {
+	r.lineAlign = align
+	return r
+}
+
+// NewLine initialize a new Line with the direction and size inherited from the Box
+func (r *Box) NewLine() *Line {
+	return &Line{
+		direction: r.direction,
+		cells:     []*Cell{},
+		gap:       -1,
+		width:     r.width,
+		height:    r.height,
+		style:     lipgloss.NewStyle(),
+	}
+}
+
+// AddLines appends additional lines to the Box
+func (r *Box) AddLines(lines ...*Line) *Box {
+	r.lines = append(r.lines, lines...)
+	r.setRecalculate()
+	return r
+}
+
+// SetLines replace lines on the Box
+func (r *Box) SetLines(lines []*Line) *Box {
+	r.lines = lines
+	r.setRecalculate()
+	return r
+}
+
+// LinesLen returns the len of the lines slice
+func (r *Box) LinesLen() int {
+	return len(r.lines)
+}
+
+// GetLine returns the Line on the given index if it exists
+// note: forces the recalculation if found
+//
+//	returns nil if not found
+func (r *Box) GetLine(index int) *Line {
+	if index >= 0 && index < len(r.lines) {
+		r.setRecalculate()
+		return r.lines[index]
+	}
+	return nil
+}
+
+// GetLineCopy returns a copy of the Line on the given index, if line does not exist it will return nil.
+// Copied line also gets copies of the cells. This is useful when you need to get lines attribute without
+// triggering a recalculation.
+func (r *Box) GetLineCopy(index int) *Line {
+	if index >= 0 && index < len(r.lines) {
+		lineCopy := r.lines[index].copy()
+		return &lineCopy
+	}
+	return nil
+}
+
+// GetLineCellCopy returns a copy of the Cell on the given cell index within the line with the given
+// line index, if line or cell do not exist it will return nil. This is useful when you need to get
+// cells attribute without triggering a recalculation.
+func (r *Box) GetLineCellCopy(lineIndex, cellIndex int) *Cell {
+	if lineIndex >= 0 && lineIndex < len(r.lines) {
+		if cellIndex >= 0 && cellIndex < len(r.lines[lineIndex].cells) {
+			cellCopy := r.lines[lineIndex].cells[cellIndex].copy()
+			return &cellCopy
+		}
+	}
+	return nil
+}
+
+// UpdateLine replaces the Line on the given index, returns an error if the line does not exist
+func (r *Box) UpdateLine(index int, line *Line) (*Box, error) {
+	if index < 0 || index >= len(r.lines) {
+		return r, ErrorIndexOutOfRange{msg: fmt.Sprintf("line index %d out of range[%d]", index, len(r.lines))}
+	}
+	r.lines[index] = line
+	r.setRecalculate()
+	return r, nil
+}
+
+// MustUpdateLine executes UpdateLine and panics if there is an error
+func (r *Box) MustUpdateLine(index int, line *Line) *Box {
+	if _, err := r.UpdateLine(index, line); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// LockLineSize sets the fixed cross size for all the lines, height of rows or width of columns,
+// this will disable scaling across the lines, value < 1 reverts to dynamic sizing
+func (r *Box) LockLineSize(value int) *Box {
+	r.fixedLineSize = value
+	r.setRecalculate()
+	return r
+}
+
+// SetHeight sets the Box height
+func (r *Box) SetHeight(value int) *Box {
+	r.height = value
+	r.setRecalculate()
+	r.applyLayout()
+	return r
+}
+
+// SetWidth sets the Box width
+func (r *Box) SetWidth(value int) *Box {
+	r.width = value
+	r.setRecalculate()
+	r.applyLayout()
+	return r
+}
+
+// GetHeight yields current Box height
+func (r *Box) GetHeight() int {
+	return r.getMaxHeight()
+}
+
+// GetWidth yields current Box width
+func (r *Box) GetWidth() int {
+	return r.getMaxWidth()
+}
+
+// Render initiates the recalculation of the lines dimensions if the recalculate flag is on,
+// and then it renders all the lines and combines them across the direction
+func (r *Box) Render() string {
+	var inheritedStyle []lipgloss.Style
+	if r.styleAncestor {
+		inheritedStyle = append(inheritedStyle, r.style)
+	}
+
+	r.recalculate()
+	r.rendered = true
+	gap, fill := r.crossGap()
+	mainSize, _ := r.direction.split(r.getContentWidth(), r.getContentHeight())
+	var renderedLines []string
+	// lines of the rendered blocks, nil for the gutters, to place them once joined
+	var renderedBy []*Line
+	shown := 0
+	for _, line := range r.lines {
+		if line.hidden {
+			continue
+		}
+		if shown++; shown > 1 && gap > 0 {
+			width, height := r.direction.join(mainSize, gap)
+			renderedLines = append(renderedLines, gutter(fill, width, height, r.gutterStyle))
+			renderedBy = append(renderedBy, nil)
+		}
+		line.rect = rect{}
+		// lines cut to nothing by the overflow are left out
+		if rendered := line.render(inheritedStyle...); rendered != "" {
+			renderedLines = append(renderedLines, rendered)
+			renderedBy = append(renderedBy, line)
+		}
+	}
+	r.placeLines(renderedLines, renderedBy)
+	var joined string
+	if r.direction == DirectionColumn {
+		joined = lipgloss.JoinHorizontal(r.lineAlign, renderedLines...)
+	} else {
+		joined = lipgloss.JoinVertical(r.lineAlign, renderedLines...)
+	}
+	// lipgloss size includes the padding
+	return r.style.
+		Width(r.getContentWidth() + r.style.GetHorizontalPadding()).MaxWidth(r.getMaxWidth()).
+		Height(r.getContentHeight() + r.style.GetVerticalPadding()).MaxHeight(r.getMaxHeight()).
+		Render(joined)
+}
+
+// ForceRecalculate forces the recalculation for the box and all the lines
+func (r *Box) ForceRecalculate() {
+	r.recalculate()
+	for _, line := range r.lines {
+		line.recalculate()
+	}
+}
+
+// recalculate fetches the line size distribution slice and sets it on the lines
+func (r *Box) recalculate() {
+	if r.recalculateFlag || r.cellRangesChanged() {
+		if len(r.lines) > 0 {
+			for _, line := range r.lines {
+				r.inheritLine(line)
+				line.evaluateRanges(r.width, r.height)
+			}
+			r.distributeLinesDimensions(r.calculateLineSize())
+		}
+		r.unsetRecalculate()
+	}
+}
+
+func (r *Box) setRecalculate() {
+	r.recalculateFlag = true
+}
+
+func (r *Box) unsetRecalculate() {
+	r.recalculateFlag = false
+}
+
+// inheritLine passes the direction, gaps and locked size of the box to the line
+func (r *Box) inheritLine(line *Line) {
+	line.direction = r.direction
+	line.boxGap, line.gutterFill = r.mainGap()
+	line.gutterStyle = r.gutterStyle
+	line.wrapGap, line.wrapFill = r.crossGap()
+	line.boxLineSize = max(r.fixedLineSize, 0)
+}
+
+// calculateLineSize calculates the cross size of each line and returns the distribution array,
+// lines with a fixed size or holding a cell with a fixed size keep it and the rest is shared by ratio,
+// wrapping lines are sized to hold all of their visual lines
+func (r *Box) calculateLineSize() (distribution []int) {
+	mainSize, crossSize := r.direction.split(r.getContentWidth(), r.getContentHeight())
+	gap, _ := r.crossGap()
+	shown := 0
+	for _, line := range r.lines {
+		if !line.hidden {
+			shown++
+		}
+	}
+	crossSize = max(crossSize-gapsSize(gap, shown), 0)
+	// hidden lines are left at zero size
+	distribution = make([]int, len(r.lines))
+	if r.fixedLineSize > 0 {
+		for i, line := range r.lines {
+			if line.hidden {
+				continue
+			}
+			distribution[i] = r.fixedLineSize
+			if fixed, _, wrapped := line.wrapCrossSize(mainSize, crossSize); wrapped {
+				distribution[i] = fixed
+			}
+		}
+		return fitOverflow(distribution, crossSize)
+	}
+
+	var dynamicLineIndices []int
+	var dynamicLineMatrix [][]int
+	totalFixedSize := 0
+
+	// first pass: identify fixed and dynamic lines
+	for i, line := range r.lines {
+		if line.hidden {
+			continue
+		}
+		maxFixedSize := line.fixedCrossSize(r.direction)
+		wrapFixed, wrapRatio, wrapped := line.wrapCrossSize(mainSize, crossSize)
+		if wrapped && maxFixedSize <= 0 {
+			maxFixedSize = wrapFixed
+		}
+		for _, cell := range line.visibleCells() {
+			if fixed := cell.fixedCrossSize(r.direction); !wrapped && fixed > maxFixedSize {
+				maxFixedSize = fixed
+			}
+		}
+
+		if maxFixedSize > 0 {
+			distribution[i] = maxFixedSize
+			totalFixedSize += maxFixedSize
+		} else {
+			dynamicLineIndices = append(dynamicLineIndices, i)
+			if wrapped {
+				// wrapping line takes the ratio of all of its visual lines
+				dynamicLineMatrix = append(dynamicLineMatrix, []int{wrapRatio})
+				continue
+			}
+			var cellValues []int
+			for _, cell := range line.visibleCells() {
+				cellValues = append(cellValues, cell.crossRatio(r.direction))
+			}
+			dynamicLineMatrix = append(dynamicLineMatrix, cellValues)
+		}
+	}
+
+	// second pass: distribute remaining space among dynamic lines
+	if len(dynamicLineIndices) > 0 {
+		remainingSize := crossSize - totalFixedSize
+		if remainingSize < 0 {
+			remainingSize = 0
+		}
+		dynamicDistribution := calculateMatrixRatio(remainingSize, dynamicLineMatrix)
+		for i, lineIndex := range dynamicLineIndices {
+			distribution[lineIndex] = dynamicDistribution[i]
+		}
+	}
+
+	// fixed lines bigger than the box are cut instead of overflowing it
+	return fitOverflow(distribution, crossSize)
+}
+
+// distributeLinesDimensions sets the size of each line per distribution array
+func (r *Box) distributeLinesDimensions(distribution []int) {
+	mainSize, _ := r.direction.split(r.getContentWidth(), r.getContentHeight())
+	for index, line := range r.lines {
+		line.setSize(r.direction.join(mainSize, distribution[index]))
+	}
+}
+
+func (r *Box) getContentWidth() int {
+	return r.getMaxWidth() - r.getExtraWidth()
+}
+
+func (r *Box) getContentHeight() int {
+	return r.getMaxHeight() - r.getExtraHeight()
+}
+
+func (r *Box) getMaxWidth() int {
+	return r.width
+}
+
+func (r *Box) getMaxHeight() int {
+	return r.height
+}
+
+func (r *Box) getExtraWidth() int {
+	return r.style.GetHorizontalFrameSize()
+}
+
+func (r *Box) getExtraHeight() int {
+	return r.style.GetVerticalFrameSize()
+}
@@ -1,6 +1,7 @@
 package flexbox
 
 import (
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -28,11 +29,43 @@ type Cell struct {
 	// fixedHeight if > 0, this cell requests a fixed height (row will use max of all cells)
 	fixedHeight int
 
+	// basis, grow and shrink size the cell along the line as flex in CSS, see SetBasis
+	basis  Basis
+	grow   int
+	shrink int
+	// maxWidth and maxHeight if > 0 cap the size of the cell
+	maxWidth  int
+	maxHeight int
+	// alignSelf places the cell across its line, see SetAlignSelf
+	alignSelf Align
+
+	// showRanges and ratioRanges are the box sizes the cell is shown in and its ratio is overridden in,
+	// hidden and ratioOverride are their state for the current box size, see ShowWhen
+	showRanges    []SizeRange
+	ratioRanges   []ratioOverride
+	hidden        bool
+	ratioOverride *ratioOverride
+	// rangesChanged marks ranges set since the last recalculation of the box holding the cell
+	rangesChanged bool
+
 	width  int
 	height int
 	// contentGenerator is a function that generates the content of the cell depending on the
 	// size of the cell, this can be useful for wrapping text or generating dynamic content.
 	contentGenerator func(maxX, maxY int) string
+	// renderable is a nested component rendered into the content size of the cell, see SetRenderable
+	renderable Renderable
+	// scroll is the viewport of the scrollable cell, nil if the cell does not scroll, see SetScrollable
+	scroll *scrollState
+
+	// rect is where the cell was rendered within its line on the last render
+	rect rect
+	// focusable, focused and focusStyle are set by the FocusManager, focusStyle is applied while the cell has the focus
+	focusable  bool
+	focused    bool
+	focusStyle func(style lipgloss.Style) lipgloss.Style
+	// msgHandler receives the messages routed to the cell, see SetMsgHandler
+	msgHandler func(msg tea.Msg) tea.Cmd
 }
 
 // NewCell initialize FlexBoxCell object with defaults
@@ -44,6 +77,8 @@ func NewCell(ratioX, ratioY int) *Cell {
 		minWidth: 0,
 		width:    0,
 		height:   0,
+		grow:     -1,
+		shrink:   -1,
 	}
 }
 
@@ -55,6 +90,7 @@ func (r *Cell) SetID(id string) *Cell {
 
 // SetContent sets the cells content
 func (r *Cell) SetContent(content string) *Cell {
+	r.renderable = nil
 	r.contentGenerator = func(_, _ int) string {
 		return content
 	}
@@ -63,12 +99,31 @@ func (r *Cell) SetContent(content string) *Cell {
 
 // SetContentGenerator sets the cells content generator function
 func (r *Cell) SetContentGenerator(generator func(maxX, maxY int) string) *Cell {
+	r.renderable = nil
 	r.contentGenerator = generator
 	return r
 }
 
+// SetRenderable sets the component rendered as the cells content, it is rendered with the size
+// of the cell without borders, margins and padding, so nested boxes follow the size of the cell
+func (r *Cell) SetRenderable(renderable Renderable) *Cell {
+	r.contentGenerator = nil
+	r.renderable = renderable
+	return r
+}
+
+// GetRenderable returns the component set with SetRenderable, nil if there is none
+func (r *Cell) GetRenderable() Renderable {
+	return r.renderable
+}
+
 // GetContent returns the cells raw content
 func (r *Cell) GetContent() string {
+	if r.renderable != nil {
+		width := max(r.getContentWidth()-r.style.GetHorizontalPadding(), 0)
+		height := max(r.getContentHeight()-r.style.GetVerticalPadding(), 0)
+		return r.renderable.RenderSized(width, height)
+	}
 	if r.contentGenerator == nil {
 		return ""
 	}
@@ -76,7 +131,7 @@ func (r *Cell) GetContent() string {
 }
 
 // SetMinWidth sets the cells minimum width, this will not disable responsivness.
-// This has only an effect to cells of a normal FlexBox, not a HorizontalFlexBox.
+// This has only an effect to cells of a row direction Box, e.g. a FlexBox.
 func (r *Cell) SetMinWidth(value int) *Cell {
 	r.minWidth = value
 	return r
@@ -84,7 +139,7 @@ func (r *Cell) SetMinWidth(value int) *Cell {
 
 // SetFixedWidth sets a fixed width for this cell, disabling proportional sizing
 // for this specific cell. Setting to -1 or 0 will revert to dynamic sizing.
-// This has only an effect to cells of a normal FlexBox, not a HorizontalFlexBox.
+// In a column direction Box, e.g. a HorizontalFlexBox, the column will use max of all cells.
 func (r *Cell) SetFixedWidth(value int) *Cell {
 	r.fixedWidth = value
 	return r
@@ -96,14 +151,15 @@ func (r *Cell) SetMinHeigth(value int) *Cell {
 }
 
 // SetMinHeight sets the cells minimum height, this will not disable responsivness.
-// This has only an effect to cells of a HorizontalFlexBox.
+// This has only an effect to cells of a column direction Box, e.g. a HorizontalFlexBox.
 func (r *Cell) SetMinHeight(value int) *Cell {
 	r.minHeight = value
 	return r
 }
 
 // SetFixedHeight sets a fixed height request for the cell
-// Note: All cells in a row will have the same height (the maximum requested)
+// Note: All cells in a row will have the same height (the maximum requested),
+// in a column direction Box the cell gets the fixed height within its column
 func (r *Cell) SetFixedHeight(value int) *Cell {
 	r.fixedHeight = value
 	return r
@@ -144,9 +200,20 @@ func (r *Cell) render(inherited ...lipgloss.Style) string {
 		r.style = r.style.Inherit(style)
 	}
 
-	s := r.GetStyle().
+	// lipgloss treats zero max size as unset, so a cell with no room renders nothing
+	if r.getMaxWidth() <= 0 || r.getMaxHeight() <= 0 {
+		return ""
+	}
+	s := r.GetStyle()
+	if r.focusStyle != nil {
+		s = r.focusStyle(s)
+	}
+	s = s.
 		Width(r.getContentWidth()).MaxWidth(r.getMaxWidth()).
 		Height(r.getContentHeight()).MaxHeight(r.getMaxHeight())
+	if r.scroll != nil {
+		return r.renderScrollable(s)
+	}
 	return s.Render(r.GetContent())
 }
 
@@ -182,5 +249,30 @@ func (r *Cell) getExtraHeight() int {
 func (r *Cell) copy() Cell {
 	cellCopy := *r
 	cellCopy.style = r.GetStyle()
+	if r.scroll != nil {
+		scrollCopy := *r.scroll
+		cellCopy.scroll = &scrollCopy
+	}
 	return cellCopy
 }
+
+// mainSizing returns the ratio, minimal and fixed size of the cell along the direction
+func (r *Cell) mainSizing(direction Direction) (ratio, minSize, fixedSize int) {
+	ratioX, ratioY := r.ratio()
+	if direction == DirectionColumn {
+		return ratioY, r.minHeight, r.fixedHeight
+	}
+	return ratioX, r.minWidth, r.fixedWidth
+}
+
+// crossRatio returns the ratio of the cell across the direction
+func (r *Cell) crossRatio(direction Direction) int {
+	_, ratio := direction.split(r.ratio())
+	return ratio
+}
+
+// fixedCrossSize returns the fixed size of the cell across the direction, the line takes the biggest one
+func (r *Cell) fixedCrossSize(direction Direction) int {
+	_, fixed := direction.split(r.fixedWidth, r.fixedHeight)
+	return fixed
+}
@@ -0,0 +1,19 @@
+package flexbox
+
+// Errors of the flexbox package are typed, they can be matched with errors.As to read the
+// message or with errors.Is against the zero value, e.g. errors.Is(err, ErrorIndexOutOfRange{})
+
+// ErrorIndexOutOfRange row, column or cell index does not exist
+type ErrorIndexOutOfRange struct {
+	msg string
+}
+
+func (e ErrorIndexOutOfRange) Error() string {
+	return e.msg
+}
+
+// Is reports whether target is an ErrorIndexOutOfRange
+func (e ErrorIndexOutOfRange) Is(target error) bool {
+	_, ok := target.(ErrorIndexOutOfRange)
+	return ok
+}
@@ -0,0 +1,348 @@
+package flexbox
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// basisKind is the way the basis of the cell is resolved
+type basisKind int
+
+const (
+	basisAuto basisKind = iota
+	basisFixed
+	basisPercent
+	basisContent
+)
+
+// Basis is the initial size of the cell along the direction of the line, free space is then shared
+// by the grow factors and overflow is taken by the shrink factors, as flex-basis in CSS
+type Basis struct {
+	kind  basisKind
+	value int
+}
+
+// BasisAuto sizes the cell by its fixed size if set, otherwise the cell starts from zero
+// and grows by its ratio, this is the default
+var BasisAuto = Basis{}
+
+// BasisFixed starts the cell from the number of terminal cells
+func BasisFixed(value int) Basis {
+	return Basis{kind: basisFixed, value: value}
+}
+
+// BasisPercent starts the cell from the percentage of the line content size
+func BasisPercent(value int) Basis {
+	return Basis{kind: basisPercent, value: value}
+}
+
+// BasisContent starts the cell from the size of its content including the frame of the cell
+func BasisContent() Basis {
+	return Basis{kind: basisContent}
+}
+
+// SetBasis sets the initial size of the cell along the direction of the line,
+// cells with a basis other than BasisAuto do not grow unless the grow factor is set
+func (r *Cell) SetBasis(basis Basis) *Cell {
+	r.basis = basis
+	return r
+}
+
+// SetGrow sets the share of the free space the cell takes, as flex-grow in CSS, the default is the ratio
+// of the cell for BasisAuto and 0 otherwise, negative value reverts to the default
+func (r *Cell) SetGrow(value int) *Cell {
+	r.grow = value
+	return r
+}
+
+// SetShrink sets the share of the overflow the cell gives up, weighted by its basis as flex-shrink in CSS,
+// cells do not shrink below their minimal size. The default is 1, and 0 for cells with a fixed size,
+// negative value reverts to the default
+func (r *Cell) SetShrink(value int) *Cell {
+	r.shrink = value
+	return r
+}
+
+// SetMaxWidth caps the width of the cell, value < 1 removes the cap
+func (r *Cell) SetMaxWidth(value int) *Cell {
+	r.maxWidth = value
+	return r
+}
+
+// SetMaxHeight caps the height of the cell, value < 1 removes the cap
+func (r *Cell) SetMaxHeight(value int) *Cell {
+	r.maxHeight = value
+	return r
+}
+
+// flexItem is the cell sizing along the direction of the line resolved for the flex algorithm
+type flexItem struct {
+	base   int
+	min    int
+	max    int
+	grow   int
+	shrink int
+}
+
+// usesFlex reports whether the cell sets any of the flex properties along the direction,
+// lines without them keep the ratio based sizing
+func (r *Cell) usesFlex(direction Direction) bool {
+	maxSize, _ := direction.split(r.maxWidth, r.maxHeight)
+	return r.basis != BasisAuto || r.grow >= 0 || r.shrink >= 0 || maxSize > 0
+}
+
+// flexItem resolves the sizing of the cell along the direction within the line content size
+func (r *Cell) flexItem(direction Direction, width, height int) flexItem {
+	ratio, minSize, fixedSize := r.mainSizing(direction)
+	available, _ := direction.split(width, height)
+	maxSize, _ := direction.split(r.maxWidth, r.maxHeight)
+	item := flexItem{min: minSize, max: maxSize, grow: ratio, shrink: 1}
+	switch r.basis.kind {
+	case basisFixed:
+		item.base, item.grow = r.basis.value, 0
+	case basisPercent:
+		item.base, item.grow = available*r.basis.value/100, 0
+	case basisContent:
+		item.base, item.grow = r.measureContent(direction, width, height), 0
+	default:
+		if fixedSize > 0 {
+			item.base, item.grow, item.shrink = fixedSize, 0, 0
+		}
+	}
+	if r.grow >= 0 {
+		item.grow = r.grow
+	}
+	if r.shrink >= 0 {
+		item.shrink = r.shrink
+	}
+	// minimal size wins over the cap as in CSS
+	if item.max > 0 && item.max < item.min {
+		item.max = item.min
+	}
+	return item
+}
+
+// measureContent returns the size of the content along the direction including the frame of the cell,
+// content is measured within the line size less the frame as GetContent renders it, nested renderables
+// implementing PreferredSizer are measured without rendering so their size is left as it is
+func (r *Cell) measureContent(direction Direction, width, height int) int {
+	width, height = max(width-r.getExtraWidth(), 0), max(height-r.getExtraHeight(), 0)
+	var contentWidth, contentHeight int
+	switch {
+	case r.renderable != nil:
+		width = max(width-r.style.GetHorizontalPadding(), 0)
+		height = max(height-r.style.GetVerticalPadding(), 0)
+		if sizer, ok := r.renderable.(PreferredSizer); ok {
+			contentWidth, contentHeight = sizer.PreferredSize(width, height)
+		} else {
+			content := r.renderable.RenderSized(width, height)
+			contentWidth, contentHeight = lipgloss.Width(content), lipgloss.Height(content)
+		}
+	case r.contentGenerator != nil:
+		content := r.contentGenerator(width, height)
+		contentWidth, contentHeight = lipgloss.Width(content), lipgloss.Height(content)
+	}
+	size, _ := direction.split(
+		contentWidth+r.getExtraWidth()+r.style.GetHorizontalPadding(),
+		contentHeight+r.getExtraHeight()+r.style.GetVerticalPadding(),
+	)
+	return size
+}
+
+// preferredSize returns the size of the line along and across the direction, its cells laid along it with
+// the gap and its frame, boxWidth and boxHeight are the size the ranges of ShowWhen are evaluated at.
+// Reports false if the line is hidden at that size
+func (r *Line) preferredSize(direction Direction, boxGap, boxWidth, boxHeight, width, height int) (mainSize, crossSize int, ok bool) {
+	if !inRanges(r.showRanges, boxWidth, boxHeight) {
+		return 0, 0, false
+	}
+	gap := boxGap
+	if r.gap >= 0 {
+		gap = r.gap
+	}
+	width, height = max(width-r.getExtraWidth(), 0), max(height-r.getExtraHeight(), 0)
+	shown := 0
+	for _, cell := range r.cells {
+		if !inRanges(cell.showRanges, boxWidth, boxHeight) {
+			continue
+		}
+		shown++
+		cellMain, cellCross := direction.split(cell.preferredSize(direction, width, height))
+		mainSize, crossSize = mainSize+cellMain, max(crossSize, cellCross)
+	}
+	if len(r.cells) > 0 && shown == 0 {
+		return 0, 0, false
+	}
+	mainSize += gapsSize(gap, shown)
+	if fixed := r.fixedCrossSize(direction); fixed > 0 {
+		crossSize = fixed
+	}
+	extraMain, extraCross := direction.split(r.getExtraWidth(), r.getExtraHeight())
+	return mainSize + extraMain, crossSize + extraCross, true
+}
+
+// preferredSize returns the width and height the cell takes within the size: its fixed size, its basis along
+// the direction or else the size of its content, kept within its min and max size
+func (r *Cell) preferredSize(direction Direction, width, height int) (int, int) {
+	preferredWidth := r.measureContent(DirectionRow, width, height)
+	preferredHeight := r.measureContent(DirectionColumn, width, height)
+	if r.fixedWidth > 0 {
+		preferredWidth = r.fixedWidth
+	}
+	if r.fixedHeight > 0 {
+		preferredHeight = r.fixedHeight
+	}
+	mainSize, crossSize := direction.split(preferredWidth, preferredHeight)
+	available, _ := direction.split(width, height)
+	switch r.basis.kind {
+	case basisFixed:
+		mainSize = r.basis.value
+	case basisPercent:
+		mainSize = available * r.basis.value / 100
+	}
+	preferredWidth, preferredHeight = direction.join(mainSize, crossSize)
+	if r.maxWidth > 0 {
+		preferredWidth = min(preferredWidth, r.maxWidth)
+	}
+	if r.maxHeight > 0 {
+		preferredHeight = min(preferredHeight, r.maxHeight)
+	}
+	return max(preferredWidth, r.minWidth), max(preferredHeight, r.minHeight)
+}
+
+// capCrossSize caps the size of the cell across the direction by its max size
+func (r *Cell) capCrossSize(direction Direction, size int) int {
+	_, maxSize := direction.split(r.maxWidth, r.maxHeight)
+	if maxSize > 0 && size > maxSize {
+		return maxSize
+	}
+	return size
+}
+
+// resolveFlex sizes the items within the available size, items start from their basis clamped
+// to their min and max, free space is shared by grow factors and overflow by shrink factors.
+// Overflow left after shrinking is resolved by fitOverflow
+func resolveFlex(items []flexItem, available int) []int {
+	sizes := make([]int, len(items))
+	used := 0
+	for i, item := range items {
+		sizes[i] = item.base
+		if item.max > 0 && sizes[i] > item.max {
+			sizes[i] = item.max
+		}
+		if sizes[i] < item.min {
+			sizes[i] = item.min
+		}
+		if sizes[i] < 0 {
+			sizes[i] = 0
+		}
+		used += sizes[i]
+	}
+
+	if free := available - used; free > 0 {
+		flexDistribute(sizes, free, func(i int) (weight, room int) {
+			room = -1
+			if items[i].max > 0 {
+				room = items[i].max - sizes[i]
+			}
+			return items[i].grow, room
+		}, 1)
+	} else if free < 0 {
+		flexDistribute(sizes, -free, func(i int) (weight, room int) {
+			// shrinking is weighted by the size as the scaled flex-shrink in CSS
+			return items[i].shrink * sizes[i], sizes[i] - items[i].min
+		}, -1)
+	}
+	return fitOverflow(sizes, available)
+}
+
+// flexDistribute shares the amount among the sizes by the weights, sign of 1 grows and -1 shrinks them,
+// each size takes no more than its room, negative room is unlimited. Sizes that run out of room are frozen
+// and the rest of the amount is shared again among the others until it is used up or nothing can take it
+func flexDistribute(sizes []int, amount int, weigh func(i int) (weight, room int), sign int) {
+	frozen := make([]bool, len(sizes))
+	for amount > 0 {
+		weights := make([]int, len(sizes))
+		rooms := make([]int, len(sizes))
+		total := 0
+		for i := range sizes {
+			if frozen[i] {
+				continue
+			}
+			weights[i], rooms[i] = weigh(i)
+			if weights[i] <= 0 || rooms[i] == 0 {
+				weights[i] = 0
+				frozen[i] = true
+				continue
+			}
+			total += weights[i]
+		}
+		if total == 0 {
+			return
+		}
+		distributed := 0
+		for i, share := range shareByWeight(amount, weights) {
+			if rooms[i] >= 0 && share >= rooms[i] {
+				share = rooms[i]
+				frozen[i] = true
+			}
+			sizes[i] += sign * share
+			distributed += share
+		}
+		if distributed == 0 {
+			return
+		}
+		amount -= distributed
+	}
+}
+
+// shareByWeight splits the amount by the weights, the remainder goes one by one to the shares
+// with the largest fractional part, ties go to the leftmost share
+func shareByWeight(amount int, weights []int) []int {
+	shares := make([]int, len(weights))
+	fractions := make([]int, len(weights))
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return shares
+	}
+	remainder := amount
+	for i, w := range weights {
+		shares[i] = amount * w / total
+		fractions[i] = amount * w % total
+		remainder -= shares[i]
+	}
+	for ; remainder > 0; remainder-- {
+		best := -1
+		for i, w := range weights {
+			if w > 0 && (best < 0 || fractions[i] > fractions[best]) {
+				best = i
+			}
+		}
+		shares[best]++
+		fractions[best] = -1
+	}
+	return shares
+}
+
+// fitOverflow is the last resort when the sizes do not fit the available size, the right most
+// sizes are cut first so the layout never overflows and no size is negative
+func fitOverflow(sizes []int, available int) []int {
+	if available < 0 {
+		available = 0
+	}
+	over := -available
+	for i, size := range sizes {
+		if size < 0 {
+			sizes[i] = 0
+		}
+		over += sizes[i]
+	}
+	for i := len(sizes) - 1; i >= 0 && over > 0; i-- {
+		cut := min(sizes[i], over)
+		sizes[i] -= cut
+		over -= cut
+	}
+	return sizes
+}
@@ -0,0 +1,111 @@
+package flexbox
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveFlex(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []flexItem
+		available int
+		want      []int
+	}{
+		{
+			name:      "grow up to max",
+			items:     []flexItem{{base: 2, grow: 1, max: 4}, {base: 2, grow: 1}},
+			available: 12,
+			want:      []int{4, 8},
+		},
+		{
+			name:      "grow by weight",
+			items:     []flexItem{{grow: 1}, {grow: 3}},
+			available: 8,
+			want:      []int{2, 6},
+		},
+		{
+			name:      "basis clamped to max",
+			items:     []flexItem{{base: 10, max: 4}},
+			available: 10,
+			want:      []int{4},
+		},
+		{
+			name:      "shrink weighted by factor and size",
+			items:     []flexItem{{base: 10, shrink: 3}, {base: 10, shrink: 1}},
+			available: 16,
+			want:      []int{7, 9},
+		},
+		{
+			name:      "shrink down to min",
+			items:     []flexItem{{base: 10, shrink: 1, min: 8}, {base: 10, shrink: 1}},
+			available: 12,
+			want:      []int{8, 4},
+		},
+		{
+			name:      "no shrink",
+			items:     []flexItem{{base: 6, shrink: 0}, {base: 6, shrink: 1}},
+			available: 8,
+			want:      []int{6, 2},
+		},
+		{
+			name:      "mins overflow cut from the right",
+			items:     []flexItem{{base: 5, min: 5, shrink: 1}, {base: 5, min: 5, shrink: 1}},
+			available: 7,
+			want:      []int{5, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveFlex(tt.items, tt.available); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveFlex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitOverflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		sizes     []int
+		available int
+		want      []int
+	}{
+		{"fits", []int{3, 4}, 8, []int{3, 4}},
+		{"last cut", []int{3, 4, 5}, 8, []int{3, 4, 1}},
+		{"cut across several", []int{3, 4, 5}, 2, []int{2, 0, 0}},
+		{"negative sizes", []int{-1, 3}, 5, []int{0, 3}},
+		{"negative available", []int{2, 2}, -1, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fitOverflow(tt.sizes, tt.available); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fitOverflow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBasisContentOfNestedBox(t *testing.T) {
+	nested := New(0, 0).SetGap(0, 1)
+	nested.AddRows([]*Row{
+		nested.NewRow().AddCells(NewCell(1, 1).SetFixedWidth(8), NewCell(1, 1).SetContent("abc")),
+		nested.NewRow().AddCells(NewCell(1, 1).SetContent("a\nb")),
+	})
+	if width, height := nested.PreferredSize(40, 10); width != 12 || height != 3 {
+		t.Fatalf("PreferredSize() = %d, %d, want 12, 3", width, height)
+	}
+	if width, height := nested.PreferredSize(5, 2); width != 5 || height != 2 {
+		t.Errorf("PreferredSize() = %d, %d, want it capped to 5, 2", width, height)
+	}
+
+	box := New(40, 5)
+	holder := NewCell(1, 1).SetBasis(BasisContent()).SetRenderable(nested)
+	rest := NewCell(1, 1)
+	box.AddRows([]*Row{box.NewRow().AddCells(holder, rest)})
+	box.Render()
+	if holder.GetWidth() != 12 || rest.GetWidth() != 28 {
+		t.Errorf("widths %d and %d, want the nested box at its content width 12 and the rest 28",
+			holder.GetWidth(), rest.GetWidth())
+	}
+}
@@ -0,0 +1,333 @@
+package flexbox
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FocusDirection is the direction the focus is moved in by FocusManager.Move
+type FocusDirection int
+
+const (
+	// FocusUp moves the focus to the nearest cell above the focused one
+	FocusUp FocusDirection = iota
+	// FocusDown moves the focus to the nearest cell below the focused one
+	FocusDown
+	// FocusLeft moves the focus to the nearest cell left of the focused one
+	FocusLeft
+	// FocusRight moves the focus to the nearest cell right of the focused one
+	FocusRight
+)
+
+// FocusKeyMap keys moving the focus, matched against tea.KeyMsg.String()
+type FocusKeyMap struct {
+	Next  []string
+	Prev  []string
+	Up    []string
+	Down  []string
+	Left  []string
+	Right []string
+}
+
+// DefaultFocusKeyMap returns the default focus key map
+func DefaultFocusKeyMap() FocusKeyMap {
+	return FocusKeyMap{
+		Next:  []string{"tab"},
+		Prev:  []string{"shift+tab"},
+		Up:    []string{"alt+up"},
+		Down:  []string{"alt+down"},
+		Left:  []string{"alt+left"},
+		Right: []string{"alt+right"},
+	}
+}
+
+// defaultFocusStyle highlights the border of the focused cell
+func defaultFocusStyle(style lipgloss.Style) lipgloss.Style {
+	return style.BorderForeground(lipgloss.Color("#7158e2"))
+}
+
+// FocusManager keeps track of the focused cell within a box and the boxes nested in it, moves the focus
+// between the focusable cells with the keys and routes the other messages to the focused cell,
+// mouse messages are routed to the cell under the pointer. Focus is moved by the geometry of the last
+// render of the box
+type FocusManager struct {
+	root    *Box
+	focused *Cell
+	style   func(style lipgloss.Style) lipgloss.Style
+	keyMap  FocusKeyMap
+	// originX and originY are the screen coordinates of the root box, used to translate mouse events
+	originX int
+	originY int
+}
+
+// NewFocusManager initialize FocusManager over the root, a Box, FlexBox or HorizontalFlexBox
+func NewFocusManager(root Renderable) *FocusManager {
+	return &FocusManager{
+		root:   nestedBox(root),
+		style:  defaultFocusStyle,
+		keyMap: DefaultFocusKeyMap(),
+	}
+}
+
+// SetFocusable sets whether the cell can take the focus from a FocusManager
+func (r *Cell) SetFocusable(value bool) *Cell {
+	r.focusable = value
+	return r
+}
+
+// IsFocusable reports whether the cell can take the focus
+func (r *Cell) IsFocusable() bool {
+	return r.focusable
+}
+
+// IsFocused reports whether the cell has the focus of a FocusManager
+func (r *Cell) IsFocused() bool {
+	return r.focused
+}
+
+// SetMsgHandler sets the handler the messages routed to the cell are passed to, e.g. the Update
+// of the component the cell holds
+func (r *Cell) SetMsgHandler(handler func(msg tea.Msg) tea.Cmd) *Cell {
+	r.msgHandler = handler
+	return r
+}
+
+// SetFocusStyle sets the overlay applied to the style of the focused cell, e.g. changing the border color.
+// Overlay should not change the size of the frame of the cell, the default highlights the border,
+// nil leaves the focused cell as it is
+func (r *FocusManager) SetFocusStyle(overlay func(style lipgloss.Style) lipgloss.Style) *FocusManager {
+	r.style = overlay
+	if r.focused != nil {
+		r.focused.focusStyle = overlay
+	}
+	return r
+}
+
+// SetOrigin sets the screen coordinates of the top left corner of the root box, mouse events are
+// translated by them, by default the box is expected at the top left corner of the screen
+func (r *FocusManager) SetOrigin(x, y int) *FocusManager {
+	r.originX, r.originY = x, y
+	return r
+}
+
+// SetKeyMap replaces the keys moving the focus
+func (r *FocusManager) SetKeyMap(keyMap FocusKeyMap) *FocusManager {
+	r.keyMap = keyMap
+	return r
+}
+
+// Focused returns the focused cell, nil if no cell has the focus
+func (r *FocusManager) Focused() *Cell {
+	return r.focused
+}
+
+// Focus moves the focus to the cell, nil or a cell that is not focusable removes the focus
+func (r *FocusManager) Focus(cell *Cell) *FocusManager {
+	if r.focused != nil {
+		r.focused.focused = false
+		r.focused.focusStyle = nil
+	}
+	r.focused = nil
+	if cell != nil && cell.focusable {
+		r.focused = cell
+		cell.focused = true
+		cell.focusStyle = r.style
+	}
+	return r
+}
+
+// FocusID moves the focus to the focusable cell with the ID, returns false if there is no such cell
+func (r *FocusManager) FocusID(id string) bool {
+	for _, placed := range r.candidates() {
+		if placed.cell.id == id {
+			r.Focus(placed.cell)
+			return true
+		}
+	}
+	return false
+}
+
+// Next moves the focus to the next focusable cell in the order of the lines and cells, wrapping around
+func (r *FocusManager) Next() *FocusManager {
+	return r.cycle(1)
+}
+
+// Prev moves the focus to the previous focusable cell in the order of the lines and cells, wrapping around
+func (r *FocusManager) Prev() *FocusManager {
+	return r.cycle(-1)
+}
+
+// Move moves the focus to the nearest focusable cell in the direction, the focus stays
+// if there is none. Without a focused cell the first focusable cell takes the focus
+func (r *FocusManager) Move(direction FocusDirection) *FocusManager {
+	candidates := r.candidates()
+	current := -1
+	for i, placed := range candidates {
+		if placed.cell == r.focused {
+			current = i
+		}
+	}
+	if current < 0 {
+		return r.cycle(1)
+	}
+	from := candidates[current].rect
+	best, bestScore := -1, 0
+	for i, placed := range candidates {
+		if i == current {
+			continue
+		}
+		if score, ok := focusScore(from, placed.rect, direction); ok && (best < 0 || score < bestScore) {
+			best, bestScore = i, score
+		}
+	}
+	if best >= 0 {
+		r.Focus(candidates[best].cell)
+	}
+	return r
+}
+
+// Update moves the focus with the keys of the FocusKeyMap and routes the other messages
+// to the focused cell, see Cell.Update. Mouse messages are routed to the innermost cell under
+// the pointer with the coordinates within its content, pressing a focusable cell focuses it.
+// Focused cell left out of the last render, e.g. hidden by ShowWhen, loses the focus.
+// Returns the command that has to be passed back to the bubbletea runtime
+func (r *FocusManager) Update(msg tea.Msg) (*FocusManager, tea.Cmd) {
+	if r.focused != nil && !r.isShown(r.focused) {
+		r.Focus(nil)
+	}
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		return r, r.routeMouse(msg)
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch key := msg.String(); {
+		case keyMatches(key, r.keyMap.Next):
+			return r.Next(), nil
+		case keyMatches(key, r.keyMap.Prev):
+			return r.Prev(), nil
+		case keyMatches(key, r.keyMap.Up):
+			return r.Move(FocusUp), nil
+		case keyMatches(key, r.keyMap.Down):
+			return r.Move(FocusDown), nil
+		case keyMatches(key, r.keyMap.Left):
+			return r.Move(FocusLeft), nil
+		case keyMatches(key, r.keyMap.Right):
+			return r.Move(FocusRight), nil
+		}
+	}
+	if r.focused == nil {
+		return r, nil
+	}
+	_, cmd := r.focused.Update(msg)
+	return r, cmd
+}
+
+// routeMouse passes the mouse message to the innermost cell under the pointer
+func (r *FocusManager) routeMouse(msg tea.MouseMsg) tea.Cmd {
+	if r.root == nil {
+		return nil
+	}
+	hit, ok := r.root.CellAt(msg.X-r.originX, msg.Y-r.originY)
+	if !ok {
+		return nil
+	}
+	if msg.Action == tea.MouseActionPress && !tea.MouseEvent(msg).IsWheel() {
+		// innermost focusable cell takes the focus
+		var focus *Cell
+		for level := &hit; level != nil; level = level.Nested {
+			if level.Cell.focusable {
+				focus = level.Cell
+			}
+		}
+		if focus != nil {
+			r.Focus(focus)
+		}
+	}
+	deepest := hit.Deepest()
+	msg.X, msg.Y = deepest.X, deepest.Y
+	_, cmd := deepest.Cell.Update(msg)
+	return cmd
+}
+
+// cycle moves the focus by the step through the focusable cells in their order
+func (r *FocusManager) cycle(step int) *FocusManager {
+	candidates := r.candidates()
+	if len(candidates) == 0 {
+		return r
+	}
+	next := 0
+	if step < 0 {
+		next = len(candidates) - 1
+	}
+	for i, placed := range candidates {
+		if placed.cell == r.focused {
+			next = (i + step + len(candidates)) % len(candidates)
+			break
+		}
+	}
+	return r.Focus(candidates[next].cell)
+}
+
+// candidates returns the focusable cells rendered on the last render of the root box
+func (r *FocusManager) candidates() []placedCell {
+	if r.root == nil {
+		return nil
+	}
+	var candidates []placedCell
+	for _, placed := range r.root.placedCells(0, 0) {
+		if placed.cell.focusable {
+			candidates = append(candidates, placed)
+		}
+	}
+	return candidates
+}
+
+// isShown reports whether the cell is one of the focusable cells of the last render, cells are
+// expected to be shown until the root box is rendered for the first time
+func (r *FocusManager) isShown(cell *Cell) bool {
+	if r.root == nil || !r.root.rendered {
+		return true
+	}
+	for _, placed := range r.candidates() {
+		if placed.cell == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// focusScore returns how far the rect is from the focused one in the direction, the distance
+// across the direction weighs double so cells in line are preferred, ok is false if the rect
+// is not in the direction
+func focusScore(from, to rect, direction FocusDirection) (score int, ok bool) {
+	var along, across int
+	switch direction {
+	case FocusUp:
+		along = from.y - (to.y + to.height)
+		across = rangeDistance(from.x, from.width, to.x, to.width)
+	case FocusDown:
+		along = to.y - (from.y + from.height)
+		across = rangeDistance(from.x, from.width, to.x, to.width)
+	case FocusLeft:
+		along = from.x - (to.x + to.width)
+		across = rangeDistance(from.y, from.height, to.y, to.height)
+	case FocusRight:
+		along = to.x - (from.x + from.width)
+		across = rangeDistance(from.y, from.height, to.y, to.height)
+	}
+	if along < 0 {
+		return 0, false
+	}
+	return along + 2*across, true
+}
+
+// rangeDistance returns the distance between two ranges, 0 if they overlap and 1 if they only touch,
+// so cells in line are preferred over the neighbouring ones
+func rangeDistance(start, size, otherStart, otherSize int) int {
+	if otherStart >= start+size {
+		return otherStart - (start + size) + 1
+	}
+	if start >= otherStart+otherSize {
+		return start - (otherStart + otherSize) + 1
+	}
+	return 0
+}
@@ -0,0 +1,214 @@
+package flexbox
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// focusBox returns a 20x4 box of two rows, a and b in the first, c and holder in the second,
+// holder holds a box of the focusable inner and the plain cell. Messages routed to the cells are recorded
+func focusBox(received map[string][]tea.Msg) *FlexBox {
+	cell := func(id string, focusable bool) *Cell {
+		return NewCell(1, 1).SetID(id).SetFocusable(focusable).SetMsgHandler(func(msg tea.Msg) tea.Cmd {
+			received[id] = append(received[id], msg)
+			return nil
+		})
+	}
+	nested := NewHorizontal(0, 0)
+	nested.AddColumns([]*Column{
+		nested.NewColumn().AddCells(cell("inner", true)),
+		nested.NewColumn().AddCells(cell("plain", false)),
+	})
+	box := New(20, 4)
+	box.AddRows([]*Row{
+		box.NewRow().AddCells(cell("a", true), cell("b", true)),
+		box.NewRow().AddCells(cell("c", true), cell("holder", true).SetRenderable(nested)),
+	})
+	box.Render()
+	return box
+}
+
+// focusedID returns the ID of the focused cell, empty if there is none
+func focusedID(manager *FocusManager) string {
+	if cell := manager.Focused(); cell != nil {
+		return cell.id
+	}
+	return ""
+}
+
+func TestFocusCycle(t *testing.T) {
+	manager := NewFocusManager(focusBox(map[string][]tea.Msg{}))
+	tests := []struct {
+		key  tea.KeyType
+		want string
+	}{
+		{tea.KeyTab, "a"},
+		{tea.KeyTab, "b"},
+		{tea.KeyTab, "c"},
+		{tea.KeyTab, "holder"},
+		{tea.KeyTab, "inner"},
+		{tea.KeyTab, "a"},
+		{tea.KeyShiftTab, "inner"},
+		{tea.KeyShiftTab, "holder"},
+	}
+	for i, tt := range tests {
+		manager.Update(tea.KeyMsg{Type: tt.key})
+		if got := focusedID(manager); got != tt.want {
+			t.Fatalf("step %d: focused %q, want %q", i, got, tt.want)
+		}
+	}
+
+	manager.Focus(nil).Prev()
+	if got := focusedID(manager); got != "inner" {
+		t.Errorf("Prev without focus focused %q, want the last cell", got)
+	}
+}
+
+func TestFocusMove(t *testing.T) {
+	manager := NewFocusManager(focusBox(map[string][]tea.Msg{}))
+	tests := []struct {
+		name      string
+		from      string
+		direction FocusDirection
+		want      string
+	}{
+		{"no focus takes the first cell", "", FocusDown, "a"},
+		{"right", "a", FocusRight, "b"},
+		{"down prefers the cell in line", "b", FocusDown, "holder"},
+		{"down from the first column", "a", FocusDown, "c"},
+		{"left", "holder", FocusLeft, "c"},
+		{"up", "c", FocusUp, "a"},
+		{"up from the nested cell", "inner", FocusUp, "b"},
+		{"nothing above keeps the focus", "a", FocusUp, "a"},
+		{"nothing right keeps the focus", "b", FocusRight, "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager.Focus(nil)
+			if tt.from != "" && !manager.FocusID(tt.from) {
+				t.Fatalf("FocusID(%q) found no cell", tt.from)
+			}
+			manager.Move(tt.direction)
+			if got := focusedID(manager); got != tt.want {
+				t.Errorf("Move() focused %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFocusMouse(t *testing.T) {
+	received := map[string][]tea.Msg{}
+	manager := NewFocusManager(focusBox(received))
+	tests := []struct {
+		name     string
+		msg      tea.MouseMsg
+		focused  string
+		receiver string
+		x, y     int
+	}{
+		{
+			name:    "press focuses the cell",
+			msg:     tea.MouseMsg{X: 12, Y: 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			focused: "b", receiver: "b", x: 2, y: 1,
+		},
+		{
+			name:    "press focuses the innermost focusable cell",
+			msg:     tea.MouseMsg{X: 11, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			focused: "inner", receiver: "inner", x: 1, y: 0,
+		},
+		{
+			name:    "press on a cell that is not focusable focuses the cell holding it",
+			msg:     tea.MouseMsg{X: 16, Y: 3, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			focused: "holder", receiver: "plain", x: 1, y: 1,
+		},
+		{
+			name:    "wheel keeps the focus",
+			msg:     tea.MouseMsg{X: 1, Y: 0, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown},
+			focused: "holder", receiver: "a", x: 1, y: 0,
+		},
+		{
+			name:    "outside the box",
+			msg:     tea.MouseMsg{X: 30, Y: 0, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			focused: "holder",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clear(received)
+			manager.Update(tt.msg)
+			if got := focusedID(manager); got != tt.focused {
+				t.Errorf("focused %q, want %q", got, tt.focused)
+			}
+			if tt.receiver == "" {
+				if len(received) > 0 {
+					t.Errorf("received %v, want no messages", received)
+				}
+				return
+			}
+			msgs := received[tt.receiver]
+			if len(received) != 1 || len(msgs) != 1 {
+				t.Fatalf("received %v, want one message for %q", received, tt.receiver)
+			}
+			if msg := msgs[0].(tea.MouseMsg); msg.X != tt.x || msg.Y != tt.y {
+				t.Errorf("message at %d,%d, want %d,%d", msg.X, msg.Y, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestFocusRoutesKeys(t *testing.T) {
+	received := map[string][]tea.Msg{}
+	manager := NewFocusManager(focusBox(received))
+	manager.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(received) > 0 {
+		t.Errorf("received %v without a focused cell", received)
+	}
+	manager.FocusID("c")
+	manager.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	manager.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if len(received) != 1 || len(received["c"]) != 1 {
+		t.Errorf("received %v, want the enter key for c only", received)
+	}
+}
+
+func TestFocusLostByHiddenCell(t *testing.T) {
+	received := map[string][]tea.Msg{}
+	handler := func(id string) func(msg tea.Msg) tea.Cmd {
+		return func(msg tea.Msg) tea.Cmd {
+			received[id] = append(received[id], msg)
+			return nil
+		}
+	}
+	wide := NewCell(1, 1).SetID("wide").SetFocusable(true).SetMsgHandler(handler("wide")).ShowWhen(WidthRange(15, 0))
+	box := New(20, 2)
+	box.AddRows([]*Row{box.NewRow().AddCells(
+		NewCell(1, 1).SetID("a").SetFocusable(true).SetMsgHandler(handler("a")),
+		wide,
+	)})
+	manager := NewFocusManager(box)
+
+	// focus set before the first render is kept
+	manager.Focus(wide)
+	manager.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	box.Render()
+	manager.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(received["wide"]) != 2 {
+		t.Fatalf("wide received %d messages, want 2", len(received["wide"]))
+	}
+
+	box.SetWidth(10)
+	box.Render()
+	manager.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if manager.Focused() != nil || wide.IsFocused() {
+		t.Errorf("hidden cell kept the focus")
+	}
+	if len(received["wide"]) != 2 || len(received["a"]) != 0 {
+		t.Errorf("received %v, want no messages after the cell was hidden", received)
+	}
+
+	manager.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := focusedID(manager); got != "a" {
+		t.Errorf("tab focused %q, want a", got)
+	}
+}
@@ -0,0 +1,117 @@
+package flexbox
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultGutterFill fills the gaps when no gutter is set
+const defaultGutterFill = " "
+
+// SetGap sets the space between the rows and between the columns of the box, as the gap in CSS.
+// In a row direction box the row gap separates the lines and the column gap separates the cells
+// of each line, in a column direction box it is the other way around. Gaps are deducted from
+// the size before it is distributed among the lines and cells
+func (r *Box) SetGap(rowGap, columnGap int) *Box {
+	r.rowGap = max(rowGap, 0)
+	r.columnGap = max(columnGap, 0)
+	r.setRecalculate()
+	return r
+}
+
+// GetGap returns the row and column gap of the box
+func (r *Box) GetGap() (int, int) {
+	return r.rowGap, r.columnGap
+}
+
+// SetGutter sets what the gaps are filled with, row fill is repeated in the gaps between the rows and
+// column fill in the gaps between the columns, e.g. "─" and "│" to draw separators. Fills should be
+// a single terminal cell wide, empty fill defaults to a space
+func (r *Box) SetGutter(rowFill, columnFill string, style lipgloss.Style) *Box {
+	r.rowGutter = rowFill
+	r.columnGutter = columnFill
+	r.gutterStyle = style
+	r.setRecalculate()
+	return r
+}
+
+// SetGap sets the space between the cells of the line overriding the gap of the box,
+// negative value reverts to the gap of the box
+func (r *Line) SetGap(value int) *Line {
+	r.gap = value
+	r.setRecalculate()
+	return r
+}
+
+// SetGap sets the space between the rows and between the cells of each row, see Box.SetGap
+func (r *FlexBox) SetGap(rowGap, columnGap int) *FlexBox {
+	r.box.SetGap(rowGap, columnGap)
+	return r
+}
+
+// SetGutter sets what the gaps between the rows and the cells are filled with, see Box.SetGutter
+func (r *FlexBox) SetGutter(rowFill, columnFill string, style lipgloss.Style) *FlexBox {
+	r.box.SetGutter(rowFill, columnFill, style)
+	return r
+}
+
+// SetGap sets the space between the cells of each column and between the columns, see Box.SetGap
+func (r *HorizontalFlexBox) SetGap(rowGap, columnGap int) *HorizontalFlexBox {
+	r.box.SetGap(rowGap, columnGap)
+	return r
+}
+
+// SetGutter sets what the gaps between the cells and the columns are filled with, see Box.SetGutter
+func (r *HorizontalFlexBox) SetGutter(rowFill, columnFill string, style lipgloss.Style) *HorizontalFlexBox {
+	r.box.SetGutter(rowFill, columnFill, style)
+	return r
+}
+
+// mainGap returns the gap between the cells of the lines and its fill
+func (r *Box) mainGap() (int, string) {
+	if r.direction == DirectionColumn {
+		return r.rowGap, r.rowGutter
+	}
+	return r.columnGap, r.columnGutter
+}
+
+// crossGap returns the gap between the lines and its fill
+func (r *Box) crossGap() (int, string) {
+	if r.direction == DirectionColumn {
+		return r.columnGap, r.columnGutter
+	}
+	return r.rowGap, r.rowGutter
+}
+
+// cellGap returns the gap between the cells of the line
+func (r *Line) cellGap() int {
+	if r.gap >= 0 {
+		return r.gap
+	}
+	return r.boxGap
+}
+
+// gapsSize returns the total size of the gaps between the items
+func gapsSize(gap, items int) int {
+	if gap <= 0 || items < 2 {
+		return 0
+	}
+	return gap * (items - 1)
+}
+
+// gutter renders the gap of the size filled with the fill
+func gutter(fill string, width, height int, style lipgloss.Style) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	if fill == "" {
+		fill = defaultGutterFill
+	}
+	line := strings.Repeat(fill, width)
+	lines := make([]string, height)
+	for i := range lines {
+		lines[i] = line
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
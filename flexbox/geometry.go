@@ -0,0 +1,199 @@
+package flexbox
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// rect is the position and size of a rendered line or cell
+type rect struct {
+	x      int
+	y      int
+	width  int
+	height int
+}
+
+// shift returns the rect moved by the offsets
+func (r rect) shift(x, y int) rect {
+	r.x += x
+	r.y += y
+	return r
+}
+
+// contains reports whether the point is within the rect
+func (r rect) contains(x, y int) bool {
+	return x >= r.x && x < r.x+r.width && y >= r.y && y < r.y+r.height
+}
+
+// empty reports whether nothing was rendered in the rect
+func (r rect) empty() bool {
+	return r.width <= 0 || r.height <= 0
+}
+
+// placedCell is a cell rendered on the last render of the root box with its position in it
+type placedCell struct {
+	cell *Cell
+	// box holding the cell and the indexes of the line and the cell in it
+	box       *Box
+	lineIndex int
+	cellIndex int
+	rect      rect
+}
+
+// CellHit is the cell found at a point by CellAt
+type CellHit struct {
+	// LineIndex and CellIndex locate the cell, the line is a row of a FlexBox or a column of a HorizontalFlexBox
+	LineIndex int
+	CellIndex int
+	CellID    string
+	Cell      *Cell
+	// X and Y are the point within the content of the cell, after its margin, border and padding,
+	// they are negative or beyond the content size when the point is on the frame of the cell
+	X int
+	Y int
+	// Nested is the hit within the box the cell holds, nil if the cell holds no box or the point misses its cells
+	Nested *CellHit
+}
+
+// Deepest returns the hit of the innermost cell at the point
+func (r CellHit) Deepest() CellHit {
+	for r.Nested != nil {
+		r = *r.Nested
+	}
+	return r
+}
+
+// CellAt returns the cell rendered at the point on the last render, coordinates are relative to the top left
+// corner of the box. The point is followed into the boxes nested in the cells, ok is false if there
+// is no cell at the point, e.g. it is on the frame of the box or in a gap
+func (r *Box) CellAt(x, y int) (hit CellHit, ok bool) {
+	for lineIndex, line := range r.lines {
+		if line.hidden || !line.rect.contains(x, y) {
+			continue
+		}
+		for cellIndex, cell := range line.cells {
+			cellRect := cell.rect.shift(line.rect.x, line.rect.y)
+			if cell.hidden || !cellRect.contains(x, y) {
+				continue
+			}
+			contentX, contentY := cell.contentOffset()
+			hit = CellHit{
+				LineIndex: lineIndex,
+				CellIndex: cellIndex,
+				CellID:    cell.id,
+				Cell:      cell,
+				X:         x - cellRect.x - contentX,
+				Y:         y - cellRect.y - contentY,
+			}
+			if nested := nestedBox(cell.renderable); nested != nil {
+				if nestedHit, found := nested.CellAt(hit.X, hit.Y); found {
+					hit.Nested = &nestedHit
+				}
+			}
+			return hit, true
+		}
+	}
+	return CellHit{}, false
+}
+
+// CellAt returns the cell rendered at the point on the last render, LineIndex of the hit is the row, see Box.CellAt
+func (r *FlexBox) CellAt(x, y int) (CellHit, bool) {
+	return r.box.CellAt(x, y)
+}
+
+// CellAt returns the cell rendered at the point on the last render, LineIndex of the hit is the column, see Box.CellAt
+func (r *HorizontalFlexBox) CellAt(x, y int) (CellHit, bool) {
+	return r.box.CellAt(x, y)
+}
+
+// placeLines sets where the rendered lines are within the box once joined across the direction,
+// blocks of the gutters have no line
+func (r *Box) placeLines(blocks []string, lines []*Line) {
+	widest, tallest := 0, 0
+	for _, block := range blocks {
+		widest = max(widest, lipgloss.Width(block))
+		tallest = max(tallest, lipgloss.Height(block))
+	}
+	x, y := frameOffset(r.style)
+	offset := 0
+	for i, block := range blocks {
+		width, height := lipgloss.Width(block), lipgloss.Height(block)
+		if line := lines[i]; line != nil {
+			if r.direction == DirectionColumn {
+				line.rect = rect{x: x + offset, y: y + joinOffset(tallest-height, r.lineAlign), width: width, height: height}
+			} else {
+				line.rect = rect{x: x + joinOffset(widest-width, r.lineAlign), y: y + offset, width: width, height: height}
+			}
+		}
+		_, size := r.direction.split(width, height)
+		offset += size
+	}
+}
+
+// placedCells returns the cells rendered on the last render with their position, the box is placed
+// at the offsets. Cells of the boxes nested in the cells follow the cell holding them
+func (r *Box) placedCells(x, y int) []placedCell {
+	var placed []placedCell
+	for lineIndex, line := range r.lines {
+		if line.hidden || line.rect.empty() {
+			continue
+		}
+		for cellIndex, cell := range line.cells {
+			if cell.hidden || cell.rect.empty() {
+				continue
+			}
+			cellRect := cell.rect.shift(line.rect.x+x, line.rect.y+y)
+			placed = append(placed, placedCell{cell: cell, box: r, lineIndex: lineIndex, cellIndex: cellIndex, rect: cellRect})
+			if nested := nestedBox(cell.renderable); nested != nil {
+				contentX, contentY := cell.contentOffset()
+				placed = append(placed, nested.placedCells(cellRect.x+contentX, cellRect.y+contentY)...)
+			}
+		}
+	}
+	return placed
+}
+
+// contentOffset returns where the content starts within the cell, after its margin, border and padding
+func (r *Cell) contentOffset() (x, y int) {
+	return frameOffset(r.style)
+}
+
+// nestedBox returns the box of the renderable if it is one, nil otherwise
+func nestedBox(renderable Renderable) *Box {
+	switch box := renderable.(type) {
+	case *Box:
+		return box
+	case *FlexBox:
+		return box.box
+	case *HorizontalFlexBox:
+		return box.box
+	}
+	return nil
+}
+
+// frameOffset returns where the content of the style starts, after its left and top margin, border and padding
+func frameOffset(style lipgloss.Style) (x, y int) {
+	x = style.GetMarginLeft() + style.GetBorderLeftSize() + style.GetPaddingLeft()
+	y = style.GetMarginTop() + style.GetBorderTopSize() + style.GetPaddingTop()
+	return x, y
+}
+
+// joinOffset returns the offset of the block within the free space as lipgloss joins blocks at the position
+func joinOffset(free int, position lipgloss.Position) int {
+	if free <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(free) * float64(position)))
+}
+
+// placeOffset returns the offset of the block within the free space as lipgloss places blocks at the position
+func placeOffset(free int, position lipgloss.Position) int {
+	if free <= 0 || position <= lipgloss.Top {
+		return 0
+	}
+	if position >= lipgloss.Bottom {
+		return free
+	}
+	return free - int(math.Round(float64(free)*float64(position)))
+}
@@ -0,0 +1,72 @@
+package flexbox
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestBoxCellAt(t *testing.T) {
+	border := lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+	nested := NewHorizontal(0, 0)
+	nested.AddColumns([]*Column{
+		nested.NewColumn().AddCells(NewCell(1, 1).SetID("left")),
+		nested.NewColumn().AddCells(NewCell(1, 1).SetID("right")),
+	})
+	// box frame is 1 border and 1 padding wide, 1 border high, content is 26x7 split by the gaps
+	// into rows of 3 lines with 12 wide cells in the first and the nested box in the second
+	box := New(30, 9).SetStyle(border.Padding(0, 1)).SetGap(1, 2)
+	box.AddRows([]*Row{
+		box.NewRow().AddCells(
+			NewCell(1, 1).SetID("framed").SetStyle(border.Padding(0, 1)),
+			NewCell(1, 1).SetID("plain"),
+		),
+		box.NewRow().AddCells(NewCell(1, 1).SetID("holder").SetRenderable(nested)),
+	})
+	box.Render()
+
+	tests := []struct {
+		name   string
+		x, y   int
+		ok     bool
+		id     string
+		localX int
+		localY int
+		deep   string
+		deepX  int
+		deepY  int
+	}{
+		{name: "box border", x: 0, y: 0},
+		{name: "box padding", x: 1, y: 1},
+		{name: "cell border", x: 2, y: 1, ok: true, id: "framed", localX: -2, localY: -1, deep: "framed"},
+		{name: "cell content after border and padding", x: 4, y: 2, ok: true, id: "framed", localX: 0, localY: 0, deep: "framed"},
+		{name: "column gap", x: 14, y: 1},
+		{name: "cell after the gap", x: 16, y: 1, ok: true, id: "plain", localX: 0, localY: 0, deep: "plain"},
+		{name: "row gap", x: 5, y: 4},
+		{name: "nested first column", x: 3, y: 5, ok: true, id: "holder", localX: 1, localY: 0, deep: "left", deepX: 1, deepY: 0},
+		{name: "nested second column", x: 16, y: 6, ok: true, id: "holder", localX: 14, localY: 1, deep: "right", deepX: 1, deepY: 1},
+		{name: "outside", x: 40, y: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, ok := box.CellAt(tt.x, tt.y)
+			if ok != tt.ok {
+				t.Fatalf("CellAt(%d, %d) ok = %v, want %v", tt.x, tt.y, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if hit.CellID != tt.id || hit.X != tt.localX || hit.Y != tt.localY {
+				t.Errorf("CellAt(%d, %d) = %q at %d,%d, want %q at %d,%d",
+					tt.x, tt.y, hit.CellID, hit.X, hit.Y, tt.id, tt.localX, tt.localY)
+			}
+			deepest := hit.Deepest()
+			if deepest.CellID != tt.deep {
+				t.Errorf("CellAt(%d, %d) deepest = %q, want %q", tt.x, tt.y, deepest.CellID, tt.deep)
+			}
+			if hit.Nested != nil && (deepest.X != tt.deepX || deepest.Y != tt.deepY) {
+				t.Errorf("CellAt(%d, %d) deepest at %d,%d, want %d,%d", tt.x, tt.y, deepest.X, deepest.Y, tt.deepX, tt.deepY)
+			}
+		})
+	}
+}
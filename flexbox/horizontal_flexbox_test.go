@@ -0,0 +1,75 @@
+package flexbox
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRatioLayoutRender(t *testing.T) {
+	border := lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+
+	flexBox := New(24, 8)
+	flexBox.AddRows([]*Row{
+		flexBox.NewRow().AddCells(NewCell(1, 1).SetContent("a"), NewCell(2, 1).SetContent("b").SetStyle(border)),
+		flexBox.NewRow().AddCells(NewCell(1, 2).SetContent("c"), NewCell(1, 2).SetContent("d"), NewCell(1, 2).SetContent("e")),
+	})
+	horizontalFlexBox := NewHorizontal(24, 8)
+	horizontalFlexBox.AddColumns([]*Column{
+		horizontalFlexBox.NewColumn().AddCells(NewCell(1, 1).SetContent("a"), NewCell(1, 2).SetContent("b").SetStyle(border)),
+		horizontalFlexBox.NewColumn().AddCells(NewCell(2, 1).SetContent("c"), NewCell(2, 1).SetContent("d"), NewCell(2, 1).SetContent("e")),
+	})
+
+	// expected output is the one rendered before FlexBox and HorizontalFlexBox were built on Box
+	tests := []struct {
+		name   string
+		render func() string
+		want   string
+	}{
+		{
+			name:   "flexbox",
+			render: flexBox.Render,
+			want: "a       ┌──────────────┐\n" +
+				"        │b             │\n" +
+				"c       d       e       \n" +
+				"                        \n" +
+				"                        \n" +
+				"                        \n" +
+				"                        \n" +
+				"                        ",
+		},
+		{
+			name:   "horizontal flexbox",
+			render: horizontalFlexBox.Render,
+			want: "a       c               \n" +
+				"                        \n" +
+				"┌──────┐                \n" +
+				"│b     │d               \n" +
+				"│      │                \n" +
+				"│      │                \n" +
+				"│      │e               \n" +
+				"└──────┘                ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.render(); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHorizontalFixedWidthSizesColumn(t *testing.T) {
+	box := NewHorizontal(20, 2)
+	box.AddColumns([]*Column{
+		box.NewColumn().AddCells(NewCell(1, 1).SetContent("a"), NewCell(1, 1).SetContent("b").SetFixedWidth(4)),
+		box.NewColumn().AddCells(NewCell(1, 1).SetContent("c")),
+	})
+	box.Render()
+	for i, want := range []int{4, 16} {
+		if got := box.GetColumn(i).GetCell(0).GetWidth(); got != want {
+			t.Errorf("column %d width = %d, want %d", i, got, want)
+		}
+	}
+}
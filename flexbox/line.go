@@ -0,0 +1,476 @@
+package flexbox
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Line is the container for the cells of a Box, this object has the least to do with the ratio
+// of the construction as it takes all of the needed ratio information from the cell slice.
+// Cells are laid along the direction of the Box, lines are stacked across it
+type Line struct {
+	// direction of the box holding the line
+	direction Direction
+
+	// style of the line
+	style         lipgloss.Style
+	styleAncestor bool
+
+	cells []*Cell
+
+	height int
+	width  int
+
+	// fixedHeight if > 0, the line of a row direction box will have a fixed height instead of ratio-based
+	fixedHeight int
+	// fixedWidth if > 0, the line of a column direction box will have a fixed width instead of ratio-based
+	fixedWidth int
+
+	// gap between the cells set on the line, negative inherits boxGap of the box
+	gap    int
+	boxGap int
+	// gutterFill and gutterStyle fill the gaps, inherited from the box
+	gutterFill  string
+	gutterStyle lipgloss.Style
+
+	// justify places the cells along the line and alignItems across it
+	justify    Justify
+	alignItems Align
+
+	// wrap breaks the cells into visual lines when they do not fit, see SetWrap
+	wrap bool
+	// wrapGap and wrapFill separate the visual lines, boxLineSize is the locked line size,
+	// all inherited from the box
+	wrapGap     int
+	wrapFill    string
+	boxLineSize int
+	// wrapped cells of the visual lines and their sizes across the direction, set on recalculation
+	wrapped      [][]*Cell
+	wrappedSizes []int
+
+	// rect is where the line was rendered within its box on the last render
+	rect rect
+
+	// showRanges are the box sizes the line is shown in and hidden its state for the current size, see ShowWhen
+	showRanges []SizeRange
+	hidden     bool
+
+	// recalculateFlag indicates if next render should make calculations regarding
+	// the cells objects height/width
+	recalculateFlag bool
+}
+
+// AddCells appends the cells to the line
+// if the cell ID is not set it will default to the index of the cell
+func (r *Line) AddCells(cells ...*Cell) *Line {
+	r.cells = append(r.cells, cells...)
+	for i, cell := range r.cells {
+		if cell.id == "" {
+			cell.id = strconv.Itoa(i)
+		}
+	}
+	r.setRecalculate()
+	return r
+}
+
+// SetFixedHeight sets a fixed height for the line of a row direction box, disabling proportional sizing
+// for this specific line. Setting to -1 or 0 will revert to dynamic sizing.
+func (r *Line) SetFixedHeight(value int) *Line {
+	r.fixedHeight = value
+	r.setRecalculate()
+	return r
+}
+
+// SetFixedWidth sets a fixed width for the line of a column direction box, disabling proportional sizing
+// for this specific line. Setting to -1 or 0 will revert to dynamic sizing.
+func (r *Line) SetFixedWidth(value int) *Line {
+	r.fixedWidth = value
+	r.setRecalculate()
+	return r
+}
+
+// CellsLen returns the len of the cells slice
+func (r *Line) CellsLen() int {
+	return len(r.cells)
+}
+
+// GetCell returns the Cell on the given index if it exists
+// note: forces the recalculation if found
+//
+//	returns nil if not found
+func (r *Line) GetCell(index int) *Cell {
+	if index >= 0 && index < len(r.cells) {
+		r.setRecalculate()
+		return r.cells[index]
+	}
+	return nil
+}
+
+// GetCellCopy returns a copy of the Cell on the given index, if cell
+// does not exist it will return nil. This is useful when you need to get
+// cells attribute without triggering a recalculation.
+func (r *Line) GetCellCopy(index int) *Cell {
+	if index >= 0 && index < len(r.cells) {
+		c := r.cells[index].copy()
+		return &c
+	}
+	return nil
+}
+
+// GetCellWithID returns the cell with the given ID if existing
+// note: forces the recalculation if found
+//
+//	returns nil if not found
+func (r *Line) GetCellWithID(id string) *Cell {
+	for _, c := range r.cells {
+		if c.id == id {
+			r.setRecalculate()
+			return c
+		}
+	}
+	return nil
+}
+
+// UpdateCellWithIndex replaces the cell on the given index if it exists
+// if its not existing no changes will apply and an error is returned
+func (r *Line) UpdateCellWithIndex(index int, cell *Cell) error {
+	if index < 0 || index >= len(r.cells) {
+		return ErrorIndexOutOfRange{msg: fmt.Sprintf("cell index %d out of range[%d]", index, len(r.cells))}
+	}
+	r.cells[index] = cell
+	r.setRecalculate()
+	return nil
+}
+
+// SetStyle replaces the style, it unsets width/height related keys
+func (r *Line) SetStyle(style lipgloss.Style) *Line {
+	r.style = style.
+		UnsetWidth().
+		UnsetMaxWidth().
+		UnsetHeight().
+		UnsetMaxHeight()
+
+	return r
+}
+
+// StylePassing set whether the style should be passed to the cells
+func (r *Line) StylePassing(value bool) *Line {
+	r.styleAncestor = value
+	return r
+}
+
+func (r *Line) setHeight(value int) {
+	r.height = value
+	r.setRecalculate()
+}
+
+func (r *Line) setWidth(value int) {
+	r.width = value
+	r.setRecalculate()
+}
+
+func (r *Line) setSize(width, height int) {
+	r.setWidth(width)
+	r.setHeight(height)
+}
+
+// fixedCrossSize returns the fixed size of the line across the direction, 0 if it is not fixed
+func (r *Line) fixedCrossSize(direction Direction) int {
+	if direction == DirectionColumn {
+		return r.fixedWidth
+	}
+	return r.fixedHeight
+}
+
+func (r *Line) render(inherited ...lipgloss.Style) string {
+	var inheritedStyle []lipgloss.Style
+
+	for _, style := range inherited {
+		r.style = r.style.Inherit(style)
+	}
+
+	// intentionally applied after line inherits the box style
+	if r.styleAncestor {
+		inheritedStyle = append(inheritedStyle, r.style)
+	}
+
+	r.recalculate()
+	for _, cell := range r.cells {
+		cell.rect = rect{}
+	}
+	if r.width <= 0 || r.height <= 0 {
+		return ""
+	}
+	mainSize, crossSize := r.direction.split(r.getContentWidth(), r.getContentHeight())
+	var joined string
+	if len(r.wrapped) > 1 {
+		joined = r.renderWrapped(inheritedStyle, mainSize)
+	} else {
+		joined = r.renderCells(r.visibleCells(), inheritedStyle, mainSize, crossSize)
+	}
+
+	var actualSize, maxSize int
+	if r.direction == DirectionColumn {
+		actualSize, maxSize = lipgloss.Height(joined), r.getMaxHeight()
+	} else {
+		actualSize, maxSize = lipgloss.Width(joined), r.getMaxWidth()
+	}
+
+	// lipgloss size includes the padding
+	style := r.style.
+		Width(r.getContentWidth() + r.style.GetHorizontalPadding()).MaxWidth(r.getMaxWidth()).
+		Height(r.getContentHeight() + r.style.GetVerticalPadding()).MaxHeight(r.getMaxHeight())
+	// Check if line content is shorter than allocated size along the direction (e.g., fixed size cells)
+	// If so, don't force the full size - let the box join handle alignment
+	if actualSize < maxSize {
+		if r.direction == DirectionColumn {
+			style = style.UnsetHeight().UnsetMaxHeight()
+		} else {
+			style = style.UnsetWidth().UnsetMaxWidth()
+		}
+	}
+	x, y := frameOffset(r.style)
+	for _, cell := range r.visibleCells() {
+		cell.rect = cell.rect.shift(x, y)
+	}
+	return style.Render(joined)
+}
+
+// renderCells renders the cells laid along the direction within the main and cross size of the line
+func (r *Line) renderCells(cells []*Cell, inheritedStyle []lipgloss.Style, mainSize, crossSize int) string {
+	gap := r.cellGap()
+	free := mainSize - gapsSize(gap, len(cells))
+	for _, cell := range cells {
+		cellSize, _ := r.direction.split(cell.width, cell.height)
+		free -= cellSize
+	}
+	// space after the last cell is left to the box alignment, see the size check in render
+	spacing := justifySpacing(r.justify, free, len(cells))
+	space := func(size int) string {
+		width, height := r.direction.join(size, crossSize)
+		return gutter(defaultGutterFill, width, height, lipgloss.NewStyle())
+	}
+
+	var renderedCells []string
+	// offset of the next cell along the line, cells are placed as they are rendered
+	offset := max(spacing[0], 0)
+	if spacing[0] > 0 {
+		renderedCells = append(renderedCells, space(spacing[0]))
+	}
+	for i, cell := range cells {
+		if i > 0 {
+			if spacing[i] > 0 {
+				renderedCells = append(renderedCells, space(spacing[i]))
+				offset += spacing[i]
+			}
+			if gap > 0 {
+				width, height := r.direction.join(gap, crossSize)
+				renderedCells = append(renderedCells, gutter(r.gutterFill, width, height, r.gutterStyle))
+				offset += gap
+			}
+		}
+		// cells cut to nothing by the overflow are left out
+		if rendered := cell.render(inheritedStyle...); rendered != "" {
+			renderedCells = append(renderedCells, r.alignCell(cell, rendered, crossSize))
+			cellMain, cellCross := r.direction.split(cell.width, cell.height)
+			x, y := r.direction.join(offset, r.cellCrossOffset(cell, crossSize-cellCross))
+			cell.rect = rect{x: x, y: y, width: cell.width, height: cell.height}
+			offset += cellMain
+		}
+	}
+
+	if r.direction == DirectionColumn {
+		return lipgloss.JoinVertical(lipgloss.Left, renderedCells...)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, renderedCells...)
+}
+
+// cellCrossOffset returns the offset of the cell across the line placed by alignCell within the free space
+func (r *Line) cellCrossOffset(cell *Cell, free int) int {
+	align := r.cellAlign(cell)
+	if free <= 0 || (align != AlignCenter && align != AlignEnd) {
+		return 0
+	}
+	return placeOffset(free, align.position())
+}
+
+// alignCell places the rendered cell across the line per its alignment
+func (r *Line) alignCell(cell *Cell, rendered string, crossSize int) string {
+	align := r.cellAlign(cell)
+	if align != AlignCenter && align != AlignEnd {
+		return rendered
+	}
+	if r.direction == DirectionColumn {
+		return lipgloss.PlaceHorizontal(crossSize, align.position(), rendered)
+	}
+	return lipgloss.PlaceVertical(crossSize, align.position(), rendered)
+}
+
+func (r *Line) setRecalculate() {
+	r.recalculateFlag = true
+}
+
+func (r *Line) unsetRecalculate() {
+	r.recalculateFlag = false
+}
+
+// recalculate fetches the cell's height/width distribution slices and sets it on the cells,
+// wrapping line is split into visual lines first
+func (r *Line) recalculate() {
+	if r.recalculateFlag {
+		r.wrapped, r.wrappedSizes = nil, nil
+		if cells := r.visibleCells(); len(cells) > 0 {
+			mainSize, crossSize := r.direction.split(r.getContentWidth(), r.getContentHeight())
+			if groups := r.wrapGroups(mainSize, crossSize); len(groups) > 1 {
+				r.wrapped = groups
+				r.wrappedSizes = r.wrappedCrossSizes(groups, crossSize)
+				for i, group := range groups {
+					r.layoutCells(group, mainSize, r.wrappedSizes[i])
+				}
+			} else {
+				r.layoutCells(cells, mainSize, crossSize)
+			}
+		}
+		r.unsetRecalculate()
+	}
+}
+
+// layoutCells sets the size of the cells laid along the direction within the main and cross size
+func (r *Line) layoutCells(cells []*Cell, mainSize, crossSize int) {
+	mainMatrix, crossMatrix := r.calculateCellsDimensions(cells, mainSize, crossSize)
+	for index, main := range mainMatrix {
+		cells[index].width, cells[index].height = r.direction.join(main, crossMatrix[index])
+	}
+}
+
+// calculateCellsDimensions calculates the size of the each cell along and across the direction
+func (r *Line) calculateCellsDimensions(cells []*Cell, mainSize, crossSize int) (mainMatrix, crossMatrix []int) {
+	width, height := r.direction.join(mainSize, crossSize)
+	mainSize = max(mainSize-gapsSize(r.cellGap(), len(cells)), 0)
+
+	// calculate the cross size, it uses fixed combined ratio since the cross size of each cell
+	// is individual and does not stack, line size will be calculated using the ratio of the
+	// biggest cell in the slice
+	crossRatios, crossRatioMax := r.getCellCrossMatrix(cells)
+	// reminder not needed here due to how combined ratio is passed
+	crossMatrix, _ = distributeToMatrix(max(crossSize, 0), crossRatioMax, crossRatios)
+	for i, cell := range cells {
+		if r.cellAlign(cell) == AlignStretch {
+			crossMatrix[i] = max(crossSize, 0)
+		}
+		crossMatrix[i] = cell.capCrossSize(r.direction, crossMatrix[i])
+	}
+
+	if r.usesFlex(cells) {
+		items := make([]flexItem, len(cells))
+		for i, cell := range cells {
+			items[i] = cell.flexItem(r.direction, width, height)
+		}
+		return resolveFlex(items, mainSize), crossMatrix
+	}
+	return fitOverflow(r.calculateCellMainSize(cells, mainSize), mainSize), crossMatrix
+}
+
+// usesFlex reports whether any of the cells sets flex properties, see Cell.SetBasis
+func (r *Line) usesFlex(cells []*Cell) bool {
+	for _, cell := range cells {
+		if cell.usesFlex(r.direction) {
+			return true
+		}
+	}
+	return false
+}
+
+// calculateCellMainSize calculates the size distribution along the direction, respecting fixed sizes
+func (r *Line) calculateCellMainSize(cells []*Cell, totalSize int) []int {
+	sizeMatrix := make([]int, len(cells))
+
+	// first pass: allocate fixed sizes and calculate remaining space
+	remainingSize := totalSize
+	var dynamicCells []int  // indices of cells with dynamic sizes
+	var dynamicRatios []int // ratios for dynamic cells
+	var minSizes []int      // min sizes for dynamic cells
+	hasMinSize := false
+
+	for i, cell := range cells {
+		ratio, minSize, fixedSize := cell.mainSizing(r.direction)
+		if fixedSize > 0 {
+			sizeMatrix[i] = fixedSize
+			remainingSize -= fixedSize
+		} else {
+			dynamicCells = append(dynamicCells, i)
+			dynamicRatios = append(dynamicRatios, ratio)
+			minSizes = append(minSizes, minSize)
+			if minSize > 0 {
+				hasMinSize = true
+			}
+		}
+	}
+
+	// second pass: distribute remaining size among dynamic cells, no remaining size leaves them at zero
+	if len(dynamicCells) > 0 && remainingSize > 0 {
+		var dynamicSizes []int
+		if hasMinSize {
+			dynamicSizes = calculateRatioWithMinimum(remainingSize, dynamicRatios, minSizes)
+		} else {
+			dynamicSizes = calculateRatio(remainingSize, dynamicRatios)
+		}
+		for i, cellIdx := range dynamicCells {
+			sizeMatrix[cellIdx] = dynamicSizes[i]
+		}
+	}
+
+	return sizeMatrix
+}
+
+// getCellCrossMatrix return the matrix of the cell ratios across the direction and the max value in it
+func (r *Line) getCellCrossMatrix(cells []*Cell) (crossMatrix []int, max int) {
+	max = 0
+	for _, cell := range cells {
+		ratio := cell.crossRatio(r.direction)
+		if ratio > max {
+			max = ratio
+		}
+		crossMatrix = append(crossMatrix, ratio)
+	}
+	return crossMatrix, max
+}
+
+func (r *Line) getContentWidth() int {
+	return r.getMaxWidth() - r.getExtraWidth()
+}
+
+func (r *Line) getContentHeight() int {
+	return r.getMaxHeight() - r.getExtraHeight()
+}
+
+func (r *Line) getMaxWidth() int {
+	return r.width
+}
+
+func (r *Line) getMaxHeight() int {
+	return r.height
+}
+
+func (r *Line) getExtraWidth() int {
+	return r.style.GetHorizontalFrameSize()
+}
+
+func (r *Line) getExtraHeight() int {
+	return r.style.GetVerticalFrameSize()
+}
+
+func (r *Line) copy() Line {
+	var cells []*Cell
+	for _, cell := range r.cells {
+		cellCopy := cell.copy()
+		cells = append(cells, &cellCopy)
+	}
+	lineCopy := *r
+	lineCopy.cells = cells
+	lineCopy.style = r.style
+
+	return lineCopy
+}
@@ -0,0 +1,79 @@
+package flexbox
+
+// Renderable is a component that renders itself into the given size, a Cell holding it with
+// SetRenderable passes its content size down on every render, so boxes can be nested into each other.
+// Box, FlexBox, HorizontalFlexBox and table.Table implement it.
+type Renderable interface {
+	RenderSized(width, height int) string
+}
+
+// PreferredSizer is implemented by renderables that report the size they take when rendered into the given
+// size without changing their state, cells sized by BasisContent measure them with it. Renderables that do
+// not implement it are rendered to be measured
+type PreferredSizer interface {
+	PreferredSize(width, height int) (int, int)
+}
+
+// RenderableFunc adapts a function to the Renderable interface, useful for small widgets
+type RenderableFunc func(width, height int) string
+
+// RenderSized calls the function
+func (f RenderableFunc) RenderSized(width, height int) string {
+	return f(width, height)
+}
+
+// RenderSized sets the size of the Box and renders it, the lines are recalculated only when the size changes
+func (r *Box) RenderSized(width, height int) string {
+	if width != r.width {
+		r.SetWidth(width)
+	}
+	if height != r.height {
+		r.SetHeight(height)
+	}
+	return r.Render()
+}
+
+// PreferredSize returns the size of the content of the box within the given size: the longest line along
+// the direction and the lines stacked across it, including the gaps and the frames. Cells take their fixed size,
+// their basis or the size of their content and lines their fixed size, cells and lines hidden by ShowWhen
+// at the given size are left out
+func (r *Box) PreferredSize(width, height int) (int, int) {
+	contentWidth := max(width-r.getExtraWidth(), 0)
+	contentHeight := max(height-r.getExtraHeight(), 0)
+	mainGap, _ := r.mainGap()
+	crossGap, _ := r.crossGap()
+	var mainSize, crossSize, shown int
+	for _, line := range r.lines {
+		lineMain, lineCross, ok := line.preferredSize(r.direction, mainGap, width, height, contentWidth, contentHeight)
+		if !ok {
+			continue
+		}
+		shown++
+		mainSize, crossSize = max(mainSize, lineMain), crossSize+lineCross
+	}
+	crossSize += gapsSize(crossGap, shown)
+	preferredWidth, preferredHeight := r.direction.join(mainSize, crossSize)
+	return min(preferredWidth+r.getExtraWidth(), width), min(preferredHeight+r.getExtraHeight(), height)
+}
+
+// PreferredSize returns the size of the content of the FlexBox within the given size, see Box.PreferredSize
+func (r *FlexBox) PreferredSize(width, height int) (int, int) {
+	return r.box.PreferredSize(width, height)
+}
+
+// PreferredSize returns the size of the content of the HorizontalFlexBox within the given size,
+// see Box.PreferredSize
+func (r *HorizontalFlexBox) PreferredSize(width, height int) (int, int) {
+	return r.box.PreferredSize(width, height)
+}
+
+// RenderSized sets the size of the FlexBox and renders it, the rows are recalculated only when the size changes
+func (r *FlexBox) RenderSized(width, height int) string {
+	return r.box.RenderSized(width, height)
+}
+
+// RenderSized sets the size of the HorizontalFlexBox and renders it, the columns are recalculated
+// only when the size changes
+func (r *HorizontalFlexBox) RenderSized(width, height int) string {
+	return r.box.RenderSized(width, height)
+}
@@ -0,0 +1,192 @@
+package flexbox
+
+import (
+	"sort"
+)
+
+// LayoutFunc builds the layout of the box for a breakpoint, e.g. replaces its lines or changes its direction
+type LayoutFunc func(box *Box)
+
+// SizeRange is a range of the box size, min values are inclusive and max values exclusive,
+// max value < 1 means there is no upper limit
+type SizeRange struct {
+	MinWidth  int
+	MaxWidth  int
+	MinHeight int
+	MaxHeight int
+}
+
+// WidthRange returns the range of the box width from minWidth up to but not including maxWidth
+func WidthRange(minWidth, maxWidth int) SizeRange {
+	return SizeRange{MinWidth: minWidth, MaxWidth: maxWidth}
+}
+
+// HeightRange returns the range of the box height from minHeight up to but not including maxHeight
+func HeightRange(minHeight, maxHeight int) SizeRange {
+	return SizeRange{MinHeight: minHeight, MaxHeight: maxHeight}
+}
+
+// Contains reports whether the size is within the range
+func (s SizeRange) Contains(width, height int) bool {
+	return width >= s.MinWidth && (s.MaxWidth < 1 || width < s.MaxWidth) &&
+		height >= s.MinHeight && (s.MaxHeight < 1 || height < s.MaxHeight)
+}
+
+// ratioOverride is the ratio of the cell within the size range, see SetRatioWhen
+type ratioOverride struct {
+	sizeRange SizeRange
+	ratioX    int
+	ratioY    int
+}
+
+// Responsive sets the layouts of the box keyed by the min width of the box they apply from, the layout
+// with the biggest key not exceeding the width is applied. Layout is applied right away and again
+// whenever SetWidth or SetHeight crosses into another breakpoint, so resizing the box on
+// tea.WindowSizeMsg is all it takes to switch layouts. Add a layout keyed by 0 to cover the
+// smallest sizes, below the smallest key the box keeps its current layout
+func (r *Box) Responsive(layouts map[int]LayoutFunc) *Box {
+	r.layouts = layouts
+	r.activeLayout = -1
+	r.applyLayout()
+	return r
+}
+
+// applyLayout applies the layout of the breakpoint the width of the box falls into, if it changed
+func (r *Box) applyLayout() {
+	breakpoints := make([]int, 0, len(r.layouts))
+	for minWidth := range r.layouts {
+		breakpoints = append(breakpoints, minWidth)
+	}
+	sort.Ints(breakpoints)
+	active := -1
+	for _, minWidth := range breakpoints {
+		if minWidth <= r.width {
+			active = minWidth
+		}
+	}
+	if active < 0 || active == r.activeLayout {
+		return
+	}
+	// set before the layout runs, so the layout can resize the box
+	r.activeLayout = active
+	r.layouts[active](r)
+	r.setRecalculate()
+}
+
+// ShowWhen sets the box sizes the cell is shown in, the cell is hidden when the size of the box holding
+// it is not within any of the ranges, e.g. WidthRange(60, 0) hides it below 60 columns. Hidden cells
+// take no space in the line, calling without ranges shows the cell at any size
+func (r *Cell) ShowWhen(ranges ...SizeRange) *Cell {
+	r.showRanges = ranges
+	r.rangesChanged = true
+	return r
+}
+
+// SetRatioWhen overrides the ratio of the cell while the size of the box holding it is within the range,
+// when several ranges match the one set last wins
+func (r *Cell) SetRatioWhen(sizeRange SizeRange, ratioX, ratioY int) *Cell {
+	r.ratioRanges = append(r.ratioRanges, ratioOverride{sizeRange: sizeRange, ratioX: ratioX, ratioY: ratioY})
+	r.rangesChanged = true
+	return r
+}
+
+// IsHidden reports whether the cell was hidden by its ranges at the last size of the box holding it
+func (r *Cell) IsHidden() bool {
+	return r.hidden
+}
+
+// ShowWhen sets the box sizes the line is shown in, the line is hidden when the size of the box
+// holding it is not within any of the ranges, calling without ranges shows the line at any size
+func (r *Line) ShowWhen(ranges ...SizeRange) *Line {
+	r.showRanges = ranges
+	r.setRecalculate()
+	return r
+}
+
+// IsHidden reports whether the line was hidden by its ranges, or by hiding all of its cells, at the last size
+// of the box holding it
+func (r *Line) IsHidden() bool {
+	return r.hidden
+}
+
+// Responsive sets the layouts of the box keyed by the min width they apply from, see Box.Responsive
+func (r *FlexBox) Responsive(layouts map[int]LayoutFunc) *FlexBox {
+	r.box.Responsive(layouts)
+	return r
+}
+
+// Responsive sets the layouts of the box keyed by the min width they apply from, see Box.Responsive
+func (r *HorizontalFlexBox) Responsive(layouts map[int]LayoutFunc) *HorizontalFlexBox {
+	r.box.Responsive(layouts)
+	return r
+}
+
+// evaluateRanges updates the visibility of the line and its cells and the ratio of the cells for the box size,
+// line with all of its cells hidden is hidden as well
+func (r *Line) evaluateRanges(width, height int) {
+	for _, cell := range r.cells {
+		hidden, override := cell.hidden, cell.ratioOverride
+		cell.evaluateRanges(width, height)
+		if cell.hidden != hidden || cell.ratioOverride != override {
+			r.setRecalculate()
+		}
+	}
+	r.hidden = !inRanges(r.showRanges, width, height) || (len(r.cells) > 0 && len(r.visibleCells()) == 0)
+}
+
+// evaluateRanges updates the visibility and the ratio of the cell for the box size
+func (r *Cell) evaluateRanges(width, height int) {
+	r.rangesChanged = false
+	r.hidden = !inRanges(r.showRanges, width, height)
+	r.ratioOverride = nil
+	for i := range r.ratioRanges {
+		if r.ratioRanges[i].sizeRange.Contains(width, height) {
+			r.ratioOverride = &r.ratioRanges[i]
+		}
+	}
+}
+
+// cellRangesChanged reports whether ranges of any cell of the box were set since the last recalculation,
+// cells do not know the box holding them so the box looks for the change when it renders
+func (r *Box) cellRangesChanged() bool {
+	for _, line := range r.lines {
+		for _, cell := range line.cells {
+			if cell.rangesChanged {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ratio returns the ratio of the cell, overridden by the range matching the box size if any
+func (r *Cell) ratio() (ratioX, ratioY int) {
+	if r.ratioOverride != nil {
+		return r.ratioOverride.ratioX, r.ratioOverride.ratioY
+	}
+	return r.ratioX, r.ratioY
+}
+
+// visibleCells returns the cells of the line that are not hidden
+func (r *Line) visibleCells() []*Cell {
+	cells := make([]*Cell, 0, len(r.cells))
+	for _, cell := range r.cells {
+		if !cell.hidden {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// inRanges reports whether the size is within any of the ranges, no ranges contain every size
+func inRanges(ranges []SizeRange, width, height int) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, sizeRange := range ranges {
+		if sizeRange.Contains(width, height) {
+			return true
+		}
+	}
+	return false
+}
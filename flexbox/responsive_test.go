@@ -0,0 +1,148 @@
+package flexbox
+
+import (
+	"testing"
+)
+
+func TestResponsiveBreakpoints(t *testing.T) {
+	var applied []string
+	layout := func(name string, cells int) LayoutFunc {
+		return func(box *Box) {
+			applied = append(applied, name)
+			line := box.NewLine()
+			for i := 0; i < cells; i++ {
+				line.AddCells(NewCell(1, 1))
+			}
+			box.SetLines([]*Line{line})
+		}
+	}
+	box := NewBox(DirectionRow, 10, 2).Responsive(map[int]LayoutFunc{
+		0:  layout("narrow", 1),
+		40: layout("medium", 2),
+		80: layout("wide", 3),
+	})
+
+	tests := []struct {
+		name    string
+		width   int
+		applied []string
+		cells   int
+	}{
+		{"applied right away", 10, []string{"narrow"}, 1},
+		{"same breakpoint is not applied again", 39, []string{"narrow"}, 1},
+		{"key is inclusive", 40, []string{"narrow", "medium"}, 2},
+		{"biggest key not exceeding the width", 120, []string{"narrow", "medium", "wide"}, 3},
+		{"back to a smaller breakpoint", 60, []string{"narrow", "medium", "wide", "medium"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box.SetWidth(tt.width)
+			box.Render()
+			if len(applied) != len(tt.applied) || applied[len(applied)-1] != tt.applied[len(tt.applied)-1] {
+				t.Errorf("applied %v, want %v", applied, tt.applied)
+			}
+			if got := len(box.GetLine(0).cells); got != tt.cells {
+				t.Errorf("line has %d cells, want %d", got, tt.cells)
+			}
+		})
+	}
+
+	// height changes within the breakpoint keep the layout
+	box.SetHeight(10)
+	if len(applied) != 4 {
+		t.Errorf("SetHeight applied %v", applied[4:])
+	}
+}
+
+func TestResponsiveBelowSmallestKey(t *testing.T) {
+	applied := 0
+	box := NewBox(DirectionRow, 10, 2).Responsive(map[int]LayoutFunc{
+		20: func(box *Box) {
+			applied++
+			box.SetLines([]*Line{box.NewLine().AddCells(NewCell(1, 1))})
+		},
+	})
+	if applied != 0 || box.LinesLen() != 0 {
+		t.Fatalf("layout applied below its key")
+	}
+	box.SetWidth(20)
+	box.SetWidth(10)
+	if applied != 1 || box.LinesLen() != 1 {
+		t.Errorf("applied %d times with %d lines, want the layout kept below the smallest key", applied, box.LinesLen())
+	}
+}
+
+func TestHideAllCellsOfRatioLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction Direction
+		// crossSize of the shown line once the other line is shown, the line takes the biggest ratio of its cells
+		crossSize int
+	}{
+		{"row", DirectionRow, 6},
+		{"column", DirectionColumn, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := NewBox(tt.direction, 20, 20)
+			hidden := box.NewLine().AddCells(
+				NewCell(1, 1).ShowWhen(WidthRange(30, 0)),
+				NewCell(2, 2).ShowWhen(WidthRange(30, 0)),
+			)
+			shown := box.NewLine().AddCells(NewCell(1, 1))
+			box.AddLines(hidden, shown)
+
+			box.Render()
+			if !hidden.IsHidden() || shown.IsHidden() {
+				t.Fatalf("hidden line IsHidden() = %v, shown line IsHidden() = %v", hidden.IsHidden(), shown.IsHidden())
+			}
+			if cell := shown.GetCell(0); cell.GetWidth() != 20 || cell.GetHeight() != 20 {
+				t.Errorf("shown cell is %dx%d, want the whole box", cell.GetWidth(), cell.GetHeight())
+			}
+
+			box.SetWidth(30)
+			box.Render()
+			if hidden.IsHidden() {
+				t.Fatalf("line is hidden at the width its cells are shown")
+			}
+			_, crossSize := tt.direction.split(shown.GetCell(0).GetWidth(), shown.GetCell(0).GetHeight())
+			if crossSize != tt.crossSize {
+				t.Errorf("shown cell cross size = %d, want %d", crossSize, tt.crossSize)
+			}
+		})
+	}
+}
+
+func TestSetRatioWhen(t *testing.T) {
+	first, second := NewCell(1, 1), NewCell(1, 1).
+		SetRatioWhen(WidthRange(0, 30), 3, 3).
+		SetRatioWhen(WidthRange(0, 25), 1, 1)
+	below := NewCell(1, 1)
+	box := NewBox(DirectionRow, 40, 8)
+	box.AddLines(box.NewLine().AddCells(first, second), box.NewLine().AddCells(below))
+
+	tests := []struct {
+		name        string
+		width       int
+		firstWidth  int
+		secondWidth int
+		lineHeight  int
+	}{
+		{"no range matches", 40, 20, 20, 4},
+		{"range overrides both ratios", 28, 7, 21, 6},
+		{"last matching range wins", 20, 10, 10, 4},
+		{"override is removed out of the range", 40, 20, 20, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box.SetWidth(tt.width)
+			box.Render()
+			if first.GetWidth() != tt.firstWidth || second.GetWidth() != tt.secondWidth {
+				t.Errorf("widths = %d, %d, want %d, %d", first.GetWidth(), second.GetWidth(), tt.firstWidth, tt.secondWidth)
+			}
+			if got := second.GetHeight(); got != tt.lineHeight {
+				t.Errorf("line height = %d, want %d", got, tt.lineHeight)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package flexbox
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// scrollWheelDelta number of lines scrolled by a single mouse wheel step
+	scrollWheelDelta = 3
+	// scrollbarTrack and scrollbarThumb draw the scrollbar when the cell has no right border
+	scrollbarTrack = "│"
+	scrollbarThumb = "┃"
+)
+
+// ScrollKeyMap keys scrolling the scrollable cell, matched against tea.KeyMsg.String()
+type ScrollKeyMap struct {
+	Up       []string
+	Down     []string
+	Left     []string
+	Right    []string
+	PageUp   []string
+	PageDown []string
+	Top      []string
+	Bottom   []string
+}
+
+// DefaultScrollKeyMap returns the default scroll key map
+func DefaultScrollKeyMap() ScrollKeyMap {
+	return ScrollKeyMap{
+		Up:       []string{"up", "k"},
+		Down:     []string{"down", "j"},
+		Left:     []string{"left", "h"},
+		Right:    []string{"right", "l"},
+		PageUp:   []string{"pgup", "ctrl+u"},
+		PageDown: []string{"pgdown", "ctrl+d"},
+		Top:      []string{"home", "g"},
+		Bottom:   []string{"end", "G"},
+	}
+}
+
+// scrollState is the viewport of the scrollable cell, sizes are taken on the last render
+type scrollState struct {
+	offsetX int
+	offsetY int
+
+	scrollbar    bool
+	followBottom bool
+	// pinned while the viewport is at the bottom, so new content keeps it there
+	pinned bool
+	// pending while the offsets set before the first render wait to be clamped
+	pending bool
+	keyMap  ScrollKeyMap
+
+	contentWidth  int
+	contentHeight int
+	viewWidth     int
+	viewHeight    int
+}
+
+// SetScrollable sets whether the cell shows its content through a viewport that can be scrolled,
+// content bigger than the cell is clipped at the scroll offsets instead of at the top left corner.
+// The cell is still sized by the box, scrolling only changes what part of the content is shown
+func (r *Cell) SetScrollable(value bool) *Cell {
+	if !value {
+		r.scroll = nil
+		return r
+	}
+	if r.scroll == nil {
+		r.scroll = &scrollState{keyMap: DefaultScrollKeyMap()}
+	}
+	return r
+}
+
+// IsScrollable reports whether the cell scrolls its content, see SetScrollable
+func (r *Cell) IsScrollable() bool {
+	return r.scroll != nil
+}
+
+// SetScrollbar sets whether the scrollable cell draws a vertical scrollbar, in the right border
+// of the cell if it has one, otherwise in the last column of the content
+func (r *Cell) SetScrollbar(value bool) *Cell {
+	r.SetScrollable(true)
+	r.scroll.scrollbar = value
+	return r
+}
+
+// SetFollowBottom sets whether the scrollable cell stays scrolled to the bottom as the content grows,
+// e.g. for a log pane. Scrolling up pauses the following and scrolling back to the bottom resumes it
+func (r *Cell) SetFollowBottom(value bool) *Cell {
+	r.SetScrollable(true)
+	r.scroll.followBottom = value
+	r.scroll.pinned = value
+	return r
+}
+
+// SetScrollKeyMap replaces the keys scrolling the cell
+func (r *Cell) SetScrollKeyMap(keyMap ScrollKeyMap) *Cell {
+	r.SetScrollable(true)
+	r.scroll.keyMap = keyMap
+	return r
+}
+
+// GetScroll returns the horizontal and vertical scroll offsets of the cell
+func (r *Cell) GetScroll() (x, y int) {
+	if r.scroll == nil {
+		return 0, 0
+	}
+	return r.scroll.offsetX, r.scroll.offsetY
+}
+
+// ScrollTo scrolls the cell to the offsets, they are clamped to the content size of the last render.
+// Offsets set before the cell is rendered are kept and clamped on the first render
+func (r *Cell) ScrollTo(x, y int) *Cell {
+	r.SetScrollable(true)
+	r.scroll.offsetX, r.scroll.offsetY = x, y
+	if !r.scroll.measured() {
+		r.scroll.pinned, r.scroll.pending = false, true
+		return r
+	}
+	r.scroll.clamp()
+	r.scroll.pinned = r.scroll.followBottom && r.scroll.atBottom()
+	return r
+}
+
+// ScrollBy scrolls the cell by the number of columns and lines, negative values scroll left and up
+func (r *Cell) ScrollBy(dx, dy int) *Cell {
+	x, y := r.GetScroll()
+	return r.ScrollTo(x+dx, y+dy)
+}
+
+// ScrollToBottom scrolls the cell to the last line of the content
+func (r *Cell) ScrollToBottom() *Cell {
+	r.SetScrollable(true)
+	r.ScrollTo(r.scroll.offsetX, r.scroll.maxOffsetY())
+	if !r.scroll.measured() {
+		// the bottom is not known yet, the first render scrolls to it
+		r.scroll.pinned = true
+	}
+	return r
+}
+
+// Update handles tea.KeyMsg and mouse wheel tea.MouseMsg scrolling the scrollable cell and passes the message
+// to the handler set with SetMsgHandler, the FocusManager routes the messages to the focused cell.
+// Returns the command that has to be passed back to the bubbletea runtime
+func (r *Cell) Update(msg tea.Msg) (*Cell, tea.Cmd) {
+	if r.scroll != nil {
+		r.handleScroll(msg)
+	}
+	if r.msgHandler != nil {
+		return r, r.msgHandler(msg)
+	}
+	return r, nil
+}
+
+// handleScroll scrolls the cell by the keys and the mouse wheel
+func (r *Cell) handleScroll(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		keyMap, page := r.scroll.keyMap, max(r.scroll.viewHeight, 1)
+		switch key := msg.String(); {
+		case keyMatches(key, keyMap.Up):
+			r.ScrollBy(0, -1)
+		case keyMatches(key, keyMap.Down):
+			r.ScrollBy(0, 1)
+		case keyMatches(key, keyMap.Left):
+			r.ScrollBy(-1, 0)
+		case keyMatches(key, keyMap.Right):
+			r.ScrollBy(1, 0)
+		case keyMatches(key, keyMap.PageUp):
+			r.ScrollBy(0, -page)
+		case keyMatches(key, keyMap.PageDown):
+			r.ScrollBy(0, page)
+		case keyMatches(key, keyMap.Top):
+			r.ScrollTo(r.scroll.offsetX, 0)
+		case keyMatches(key, keyMap.Bottom):
+			r.ScrollToBottom()
+		}
+	case tea.MouseMsg:
+		if !tea.MouseEvent(msg).IsWheel() || msg.Action != tea.MouseActionPress {
+			return
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			r.ScrollBy(0, -scrollWheelDelta)
+		case tea.MouseButtonWheelDown:
+			r.ScrollBy(0, scrollWheelDelta)
+		case tea.MouseButtonWheelLeft:
+			r.ScrollBy(-scrollWheelDelta, 0)
+		case tea.MouseButtonWheelRight:
+			r.ScrollBy(scrollWheelDelta, 0)
+		}
+	}
+}
+
+// renderScrollable renders the scrollable cell, the content is cut to the viewport at the scroll offsets
+func (r *Cell) renderScrollable(style lipgloss.Style) string {
+	state := r.scroll
+	borderBar := state.scrollbar && style.GetBorderRight()
+	viewWidth := max(r.getContentWidth()-r.style.GetHorizontalPadding(), 0)
+	viewHeight := max(r.getContentHeight()-r.style.GetVerticalPadding(), 0)
+	if state.scrollbar && !borderBar {
+		viewWidth = max(viewWidth-1, 0)
+	}
+
+	lines := strings.Split(r.GetContent(), "\n")
+	state.contentWidth = 0
+	for _, line := range lines {
+		state.contentWidth = max(state.contentWidth, ansi.StringWidth(line))
+	}
+	state.contentHeight = len(lines)
+	state.viewWidth, state.viewHeight = viewWidth, viewHeight
+	if state.pinned {
+		state.offsetY = state.maxOffsetY()
+	}
+	state.clamp()
+	if state.pending {
+		state.pinned, state.pending = state.followBottom && state.atBottom(), false
+	}
+
+	view := make([]string, viewHeight)
+	for i := range view {
+		if index := state.offsetY + i; index < len(lines) {
+			view[i] = ansi.Truncate(skipColumns(lines[index], state.offsetX), viewWidth, "")
+		}
+	}
+	content := strings.Join(view, "\n")
+	if !state.scrollbar {
+		return style.Render(content)
+	}
+	if !borderBar {
+		bar := state.bar(viewHeight, scrollbarTrack, scrollbarThumb)
+		content = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(viewWidth).Render(content), strings.Join(bar, "\n"))
+		return style.Render(content)
+	}
+
+	// scrollbar replaces the right border, the rest of the cell is rendered without it
+	border := style.GetBorderStyle()
+	rendered := style.BorderRight(false).Margin(0).
+		MaxWidth(r.getMaxWidth() - style.GetHorizontalMargins() - 1).
+		MaxHeight(r.getMaxHeight() - style.GetVerticalMargins()).
+		Render(content)
+	barStyle := lipgloss.NewStyle().
+		Foreground(style.GetBorderRightForeground()).
+		Background(style.GetBorderRightBackground())
+	var bar []string
+	if style.GetBorderTop() {
+		bar = append(bar, border.TopRight)
+	}
+	bar = append(bar, state.bar(r.getContentHeight(), border.Right, scrollbarThumb)...)
+	if style.GetBorderBottom() {
+		bar = append(bar, border.BottomRight)
+	}
+	rendered = lipgloss.JoinHorizontal(lipgloss.Top, rendered, barStyle.Render(strings.Join(bar, "\n")))
+	return lipgloss.NewStyle().Margin(style.GetMargin()).Render(rendered)
+}
+
+// bar returns the rows of the vertical scrollbar of the size, the thumb shows the visible part of the content
+func (s *scrollState) bar(size int, track, thumb string) []string {
+	if size <= 0 {
+		return nil
+	}
+	rows := make([]string, size)
+	thumbSize, thumbStart := size, 0
+	if s.contentHeight > s.viewHeight && s.viewHeight > 0 {
+		thumbSize = max(size*s.viewHeight/s.contentHeight, 1)
+		thumbStart = (size - thumbSize) * s.offsetY / s.maxOffsetY()
+	}
+	for i := range rows {
+		rows[i] = track
+		if i >= thumbStart && i < thumbStart+thumbSize {
+			rows[i] = thumb
+		}
+	}
+	return rows
+}
+
+// maxOffsetY returns the vertical offset showing the last line of the content
+func (s *scrollState) maxOffsetY() int {
+	return max(s.contentHeight-s.viewHeight, 0)
+}
+
+// measured reports whether the sizes were taken by a render, the content has at least one line once rendered
+func (s *scrollState) measured() bool {
+	return s.contentHeight > 0
+}
+
+// atBottom reports whether the last line of the content is visible
+func (s *scrollState) atBottom() bool {
+	return s.offsetY >= s.maxOffsetY()
+}
+
+// clamp keeps the offsets within the content
+func (s *scrollState) clamp() {
+	s.offsetX = max(min(s.offsetX, s.contentWidth-s.viewWidth), 0)
+	s.offsetY = max(min(s.offsetY, s.maxOffsetY()), 0)
+}
+
+// skipColumns drops the first columns of the line keeping the escape sequences, so the styles
+// of the rest of the line are kept, wide characters cut in half are replaced by spaces
+func skipColumns(line string, columns int) string {
+	if columns <= 0 {
+		return line
+	}
+	var b strings.Builder
+	skipped := 0
+	for i := 0; i < len(line); {
+		if line[i] == ansi.ESC {
+			end := escapeEnd(line, i)
+			b.WriteString(line[i:end])
+			i = end
+			continue
+		}
+		if skipped >= columns {
+			b.WriteString(line[i:])
+			break
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		width := ansi.StringWidth(string(r))
+		if skipped+width > columns {
+			b.WriteString(strings.Repeat(" ", skipped+width-columns))
+		}
+		skipped += width
+		i += size
+	}
+	return b.String()
+}
+
+// escapeEnd returns the index after the escape sequence starting at the index
+func escapeEnd(line string, start int) int {
+	i := start + 1
+	if i >= len(line) {
+		return i
+	}
+	switch line[i] {
+	case '[':
+		// CSI ends with a byte in the 0x40-0x7e range
+		for i++; i < len(line); i++ {
+			if line[i] >= 0x40 && line[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		// OSC ends with BEL or ST
+		for i++; i < len(line); i++ {
+			if line[i] == ansi.BEL {
+				return i + 1
+			}
+			if line[i] == ansi.ESC && i+1 < len(line) && line[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return i + 1
+	}
+	return len(line)
+}
+
+// keyMatches reports whether the key is one of the keys
+func keyMatches(key string, keys []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
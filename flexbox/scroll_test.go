@@ -0,0 +1,157 @@
+package flexbox
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSkipColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		columns int
+		want    string
+	}{
+		{"nothing skipped", "abc", 0, "abc"},
+		{"ascii", "abcdef", 2, "cdef"},
+		{"past the end", "abc", 5, ""},
+		{"wide character skipped", "世界ab", 2, "界ab"},
+		{"wide character cut in half", "世界ab", 1, " 界ab"},
+		{"csi kept", "\x1b[31mabc\x1b[0m", 1, "\x1b[31mbc\x1b[0m"},
+		{"csi before skipped text", "ab\x1b[1mcd", 3, "\x1b[1md"},
+		{"osc with bel", "\x1b]8;;http://x\x07link\x1b]8;;\x07", 2, "\x1b]8;;http://x\x07nk\x1b]8;;\x07"},
+		{"osc with st", "\x1b]8;;http://x\x1b\\link", 3, "\x1b]8;;http://x\x1b\\k"},
+		{"styled wide character cut", "\x1b[31m世\x1b[0mx", 1, "\x1b[31m \x1b[0mx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := skipColumns(tt.line, tt.columns); got != tt.want {
+				t.Errorf("skipColumns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeEnd(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		start int
+		want  int
+	}{
+		{"csi", "\x1b[31mx", 0, 5},
+		{"csi with parameters", "a\x1b[38;5;1mx", 1, 10},
+		{"unterminated csi", "\x1b[31", 0, 4},
+		{"osc with bel", "\x1b]0;title\x07x", 0, 10},
+		{"osc with st", "\x1b]0;title\x1b\\x", 0, 11},
+		{"unterminated osc", "\x1b]0;title", 0, 9},
+		{"two byte sequence", "\x1b7x", 0, 2},
+		{"lone escape", "x\x1b", 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeEnd(tt.line, tt.start); got != tt.want {
+				t.Errorf("escapeEnd() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// scrollBox returns a box of the size holding a single scrollable cell showing the lines
+func scrollBox(width, height int, lines *[]string) (*FlexBox, *Cell) {
+	cell := NewCell(1, 1).SetScrollable(true).SetContentGenerator(func(_, _ int) string {
+		return strings.Join(*lines, "\n")
+	})
+	box := New(width, height)
+	box.AddRows([]*Row{box.NewRow().AddCells(cell)})
+	return box, cell
+}
+
+// numbered returns the lines "0" to "n-1"
+func numbered(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprint(i)
+	}
+	return lines
+}
+
+func TestScrollToBeforeRender(t *testing.T) {
+	lines := numbered(10)
+	box, cell := scrollBox(4, 3, &lines)
+	cell.ScrollTo(0, 4)
+	if got := box.Render(); got != "4   \n5   \n6   " {
+		t.Errorf("Render() = %q, want the lines from 4", got)
+	}
+
+	cell.ScrollTo(0, 20)
+	box.Render()
+	if _, y := cell.GetScroll(); y != 7 {
+		t.Errorf("offset after render = %d, want clamped to 7", y)
+	}
+}
+
+func TestScrollToBottomBeforeRender(t *testing.T) {
+	lines := numbered(10)
+	box, cell := scrollBox(4, 3, &lines)
+	cell.ScrollToBottom()
+	box.Render()
+	if _, y := cell.GetScroll(); y != 7 {
+		t.Errorf("offset = %d, want 7", y)
+	}
+
+	// without following the bottom the cell stays where it is as the content grows
+	lines = numbered(12)
+	box.Render()
+	if _, y := cell.GetScroll(); y != 7 {
+		t.Errorf("offset after growing = %d, want 7", y)
+	}
+}
+
+func TestFollowBottom(t *testing.T) {
+	lines := numbered(5)
+	box, cell := scrollBox(4, 3, &lines)
+	cell.SetFollowBottom(true)
+
+	steps := []struct {
+		name   string
+		lines  int
+		scroll func()
+		want   int
+	}{
+		{"pinned on the first render", 5, nil, 2},
+		{"follows growing content", 8, nil, 5},
+		{"scrolling up pauses", 8, func() { cell.ScrollBy(0, -2) }, 3},
+		{"paused while growing", 10, nil, 3},
+		{"scrolling to the bottom resumes", 10, func() { cell.ScrollToBottom() }, 7},
+		{"follows again", 12, nil, 9},
+		{"scrolling down to the bottom resumes", 12, func() { cell.ScrollBy(0, -1); cell.ScrollBy(0, 1) }, 9},
+		{"follows after scrolling down", 13, nil, 10},
+	}
+	for _, step := range steps {
+		lines = numbered(step.lines)
+		if step.scroll != nil {
+			step.scroll()
+		}
+		box.Render()
+		if _, y := cell.GetScroll(); y != step.want {
+			t.Errorf("%s: offset = %d, want %d", step.name, y, step.want)
+		}
+	}
+}
+
+func TestFollowBottomScrollToBeforeRender(t *testing.T) {
+	lines := numbered(10)
+	box, cell := scrollBox(4, 3, &lines)
+	cell.SetFollowBottom(true).ScrollTo(0, 2)
+	box.Render()
+	if _, y := cell.GetScroll(); y != 2 {
+		t.Errorf("offset = %d, want 2", y)
+	}
+	lines = numbered(12)
+	box.Render()
+	if _, y := cell.GetScroll(); y != 2 {
+		t.Errorf("offset after growing = %d, want 2 as the cell is not at the bottom", y)
+	}
+}
@@ -30,24 +30,22 @@ func calculateRatioWithMinimum(distribute int, matrix []int, minimumMatrix []int
 			ratioDistribution[i] = d
 		}
 	}
-	// TODO: calculate remainder and if negative shrink right most column
+	// negative remainder, when the minimums do not fit, is resolved by fitOverflow
 
 	return ratioDistribution
 }
 
 func calculateRatio(distribute int, matrix []int) (ratioDistribution []int) {
-	if distribute == 0 {
-		for range matrix {
-			ratioDistribution = append(ratioDistribution, 0)
-		}
-		return ratioDistribution
-	}
-
 	var combinedRatios int
 	for _, value := range matrix {
 		combinedRatios += value
 	}
 
+	// nothing to distribute or no ratio to distribute it by leaves every value at zero
+	if distribute == 0 || combinedRatios <= 0 {
+		return make([]int, len(matrix))
+	}
+
 	if combinedRatios > 0 {
 		var remainder int
 		ratioDistribution, remainder = distributeToMatrix(distribute, combinedRatios, matrix)
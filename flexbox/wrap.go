@@ -0,0 +1,148 @@
+package flexbox
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetWrap sets whether the cells that do not fit the line are moved onto additional visual lines,
+// as flex-wrap in CSS. Cells are fitted by their basis, fixed or minimal size, whichever is the biggest,
+// and the box sizes the line to hold all of its visual lines. Wrapping is off by default
+func (r *Line) SetWrap(value bool) *Line {
+	r.wrap = value
+	r.setRecalculate()
+	return r
+}
+
+// GetWrap returns whether the line wraps its cells
+func (r *Line) GetWrap() bool {
+	return r.wrap
+}
+
+// wrapSize returns the size the cell needs along the direction to be placed on a visual line
+func (r *Cell) wrapSize(direction Direction, width, height int) int {
+	item := r.flexItem(direction, width, height)
+	size := max(item.base, item.min)
+	if item.max > 0 && size > item.max {
+		size = item.max
+	}
+	return size
+}
+
+// wrapGroups splits the cells into the visual lines within the main size, every visual line
+// holds at least one cell, line that does not wrap is a single visual line
+func (r *Line) wrapGroups(mainSize, crossSize int) [][]*Cell {
+	cells := r.visibleCells()
+	if !r.wrap || len(cells) < 2 {
+		return [][]*Cell{cells}
+	}
+	width, height := r.direction.join(mainSize, crossSize)
+	gap := r.cellGap()
+	var groups [][]*Cell
+	var group []*Cell
+	used := 0
+	for _, cell := range cells {
+		size := cell.wrapSize(r.direction, width, height)
+		if len(group) > 0 && used+max(gap, 0)+size > mainSize {
+			groups = append(groups, group)
+			group, used = nil, 0
+		}
+		if len(group) > 0 {
+			used += max(gap, 0)
+		}
+		group = append(group, cell)
+		used += size
+	}
+	return append(groups, group)
+}
+
+// wrapCrossSize returns the size across the direction the wrapping line needs within the main size
+// of the box, fixed when all of its visual lines are fixed and the summed ratio of the visual lines
+// otherwise, wrapped is false when the cells fit a single visual line
+func (r *Line) wrapCrossSize(mainSize, crossSize int) (fixed, ratio int, wrapped bool) {
+	extra, _ := r.direction.split(r.getExtraWidth(), r.getExtraHeight())
+	groups := r.wrapGroups(mainSize-extra, crossSize)
+	if len(groups) < 2 {
+		return 0, 0, false
+	}
+	for _, group := range groups {
+		groupFixed, groupRatio := r.groupCrossSizing(group)
+		if groupFixed <= 0 {
+			fixed = -1
+		} else if fixed >= 0 {
+			fixed += groupFixed
+		}
+		ratio += groupRatio
+	}
+	if fixed > 0 {
+		fixed += gapsSize(r.wrapGap, len(groups))
+	}
+	return max(fixed, 0), ratio, true
+}
+
+// groupCrossSizing returns the fixed size and ratio of the visual line across the direction,
+// the size locked on the box wins over the biggest fixed size of the cells
+func (r *Line) groupCrossSizing(cells []*Cell) (fixed, ratio int) {
+	fixed = r.boxLineSize
+	for _, cell := range cells {
+		if r.boxLineSize <= 0 && cell.fixedCrossSize(r.direction) > fixed {
+			fixed = cell.fixedCrossSize(r.direction)
+		}
+		ratio = max(ratio, cell.crossRatio(r.direction))
+	}
+	return max(fixed, 0), ratio
+}
+
+// wrappedCrossSizes distributes the cross size of the line among the visual lines,
+// fixed visual lines keep their size and the rest is shared by ratio
+func (r *Line) wrappedCrossSizes(groups [][]*Cell, crossSize int) []int {
+	available := max(crossSize-gapsSize(r.wrapGap, len(groups)), 0)
+	sizes := make([]int, len(groups))
+	ratios := make([]int, len(groups))
+	remaining := available
+	dynamic := 0
+	for i, group := range groups {
+		fixed, ratio := r.groupCrossSizing(group)
+		if fixed > 0 {
+			sizes[i] = fixed
+			remaining -= fixed
+			continue
+		}
+		// visual lines without any ratio share the space equally
+		ratios[i] = max(ratio, 1)
+		dynamic++
+	}
+	if dynamic > 0 && remaining > 0 {
+		for i, share := range shareByWeight(remaining, ratios) {
+			sizes[i] += share
+		}
+	}
+	return fitOverflow(sizes, available)
+}
+
+// renderWrapped renders the visual lines of the line stacked across the direction
+func (r *Line) renderWrapped(inheritedStyle []lipgloss.Style, mainSize int) string {
+	var rendered []string
+	// offset of the next visual line across the line, cells are shifted by it once rendered
+	offset := 0
+	for i, group := range r.wrapped {
+		if i > 0 && r.wrapGap > 0 {
+			width, height := r.direction.join(mainSize, r.wrapGap)
+			rendered = append(rendered, gutter(r.wrapFill, width, height, r.gutterStyle))
+			offset += r.wrapGap
+		}
+		// visual lines cut to nothing by the overflow are left out
+		if r.wrappedSizes[i] > 0 {
+			visual := r.renderCells(group, inheritedStyle, mainSize, r.wrappedSizes[i])
+			rendered = append(rendered, visual)
+			for _, cell := range group {
+				cell.rect = cell.rect.shift(r.direction.join(0, offset))
+			}
+			_, size := r.direction.split(lipgloss.Width(visual), lipgloss.Height(visual))
+			offset += size
+		}
+	}
+	if r.direction == DirectionColumn {
+		return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rendered...)
+}
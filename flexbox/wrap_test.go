@@ -0,0 +1,51 @@
+package flexbox
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrapGroups(t *testing.T) {
+	fixed := func(sizes ...int) []*Cell {
+		var cells []*Cell
+		for _, size := range sizes {
+			cells = append(cells, NewCell(1, 1).SetBasis(BasisFixed(size)))
+		}
+		return cells
+	}
+	tests := []struct {
+		name  string
+		cells []*Cell
+		gap   int
+		wrap  bool
+		want  [][]int
+	}{
+		{"fits a single line", fixed(3, 3, 3), 0, true, [][]int{{0, 1, 2}}},
+		{"wraps the overflow", fixed(4, 4, 4), 0, true, [][]int{{0, 1}, {2}}},
+		{"gap fits exactly", fixed(4, 4, 4), 2, true, [][]int{{0, 1}, {2}}},
+		{"gap pushes the cell over", fixed(4, 4, 4), 3, true, [][]int{{0}, {1}, {2}}},
+		{"cell bigger than the line", fixed(12, 3, 3), 0, true, [][]int{{0}, {1, 2}}},
+		{"min size", []*Cell{NewCell(1, 1).SetMinWidth(6), NewCell(1, 1).SetMinWidth(6)}, 0, true, [][]int{{0}, {1}}},
+		{"wrap off", fixed(4, 4, 4), 0, false, [][]int{{0, 1, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := NewBox(DirectionRow, 10, 4).NewLine().SetWrap(tt.wrap).SetGap(tt.gap).AddCells(tt.cells...)
+			var got [][]int
+			for _, group := range line.wrapGroups(10, 4) {
+				var indexes []int
+				for _, cell := range group {
+					for i, c := range tt.cells {
+						if c == cell {
+							indexes = append(indexes, i)
+						}
+					}
+				}
+				got = append(got, indexes)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// CopyFormat is the serialization format used when copying to the clipboard
+type CopyFormat int
+
+const (
+	// CopyFormatTSV tab separated values, tabs and new lines within values are replaced by spaces
+	CopyFormatTSV CopyFormat = iota
+	// CopyFormatCSV comma separated values
+	CopyFormatCSV
+	// CopyFormatJSON rows are serialized as objects keyed by the column headers, in the displayed column order
+	CopyFormatJSON
+)
+
+// ClipboardErrorMsg is emitted when the command returned by the Copy* methods fails to write the sequence
+type ClipboardErrorMsg struct {
+	Err error
+}
+
+// SetClipboardOutput sets the writer the commands returned by the Copy* methods write OSC 52 sequences to,
+// it can be used to capture the sequence in tests, nil reverts to os.Stdout, the terminal of the program
+func (r *Table) SetClipboardOutput(w io.Writer) *Table {
+	r.clipboardOutput = w
+	return r
+}
+
+// ToggleRowSelection selects or deselects the row under the cursor, selected rows can be copied with CopySelection
+func (r *Table) ToggleRowSelection() *Table {
+	if r.cursorIndexY < 0 || r.cursorIndexY >= len(r.filteredRows) {
+		return r
+	}
+	key := r.rowID(r.filteredRows[r.cursorIndexY])
+	if _, ok := r.selectedRows[key]; ok {
+		delete(r.selectedRows, key)
+	} else {
+		r.selectedRows[key] = struct{}{}
+	}
+	r.setRowsUpdate()
+	return r
+}
+
+// ClearSelection deselects all the rows
+func (r *Table) ClearSelection() *Table {
+	r.selectedRows = make(map[uint64]struct{})
+	r.setRowsUpdate()
+	return r
+}
+
+// GetSelectedRows returns the selected rows that are visible after filtering, in the order they are displayed
+func (r *Table) GetSelectedRows() [][]any {
+	var selected [][]any
+	for _, row := range r.filteredRows {
+		if r.isRowSelected(row) {
+			selected = append(selected, row)
+		}
+	}
+	return selected
+}
+
+// CopyCell copies the value of the cell under the cursor to the clipboard, returns the command
+// that has to be passed back to the bubbletea runtime. There is no cell under the cursor unless
+// the cursor mode tracks both the row and the column
+func (r *Table) CopyCell(format CopyFormat) (tea.Cmd, error) {
+	if len(r.filteredRows) == 0 || !r.cursorMode.tracksRow() || !r.cursorMode.tracksColumn() || r.cursorColumn() < 0 {
+		return nil, ErrorIndexOutOfRange{msg: "there is no cell under the cursor"}
+	}
+	value := r.cellValue(r.filteredRows[r.cursorIndexY], r.cursorColumn())
+	var out string
+	switch format {
+	case CopyFormatJSON:
+		b, err := json.Marshal(jsonValue(value))
+		if err != nil {
+			return nil, err
+		}
+		out = string(b)
+	case CopyFormatCSV:
+		s, err := serializeRows(nil, [][]string{{copyString(value)}}, format)
+		if err != nil {
+			return nil, err
+		}
+		out = strings.TrimSuffix(s, "\n")
+	default:
+		out = tsvEscape(copyString(value))
+	}
+	return r.writeClipboard(out), nil
+}
+
+// CopyRow copies the row under the cursor to the clipboard, without the headers,
+// rows are copied with the displayed columns only, in the order they are displayed
+func (r *Table) CopyRow(format CopyFormat) (tea.Cmd, error) {
+	if len(r.filteredRows) == 0 || !r.cursorMode.tracksRow() {
+		return nil, ErrorIndexOutOfRange{msg: "there is no row under the cursor"}
+	}
+	return r.copyRows([][]any{r.filteredRows[r.cursorIndexY]}, format, false)
+}
+
+// CopySelection copies the selected rows to the clipboard, including the headers
+func (r *Table) CopySelection(format CopyFormat) (tea.Cmd, error) {
+	return r.copyRows(r.GetSelectedRows(), format, true)
+}
+
+// CopyView copies all the rows that are visible after filtering to the clipboard, including the headers
+func (r *Table) CopyView(format CopyFormat) (tea.Cmd, error) {
+	return r.copyRows(r.filteredRows, format, true)
+}
+
+// copyRows serializes the rows and writes them to the clipboard
+func (r *Table) copyRows(rows [][]any, format CopyFormat, withHeaders bool) (tea.Cmd, error) {
+	var out string
+	var err error
+	if format == CopyFormatJSON {
+		objects := []json.RawMessage{}
+		for _, row := range rows {
+			object, err := r.jsonObject(row)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, object)
+		}
+		// single row is copied as an object rather than an array
+		if !withHeaders && len(objects) == 1 {
+			out = string(objects[0])
+		} else {
+			b, err := json.Marshal(objects)
+			if err != nil {
+				return nil, err
+			}
+			out = string(b)
+		}
+	} else {
+		var headers []string
+		if withHeaders {
+			headers = []string{}
+			for _, i := range r.columnView {
+				headers = append(headers, r.columnHeaders[i])
+			}
+		}
+		var records [][]string
+		for _, row := range rows {
+			var record []string
+			for _, i := range r.columnView {
+				record = append(record, copyString(r.cellValue(row, i)))
+			}
+			records = append(records, record)
+		}
+		if out, err = serializeRows(headers, records, format); err != nil {
+			return nil, err
+		}
+	}
+	return r.writeClipboard(out), nil
+}
+
+// writeClipboard wraps the string into OSC 52 sequence and returns the command writing it to the clipboard
+// output, the sequence is written on its own so it never ends up within the rendered table. Sequence is
+// additionally escaped when running inside tmux or screen
+func (r *Table) writeClipboard(s string) tea.Cmd {
+	seq := osc52.New(s)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	out := r.clipboardOutput
+	if out == nil {
+		out = os.Stdout
+	}
+	return func() tea.Msg {
+		if _, err := seq.WriteTo(out); err != nil {
+			return ClipboardErrorMsg{Err: fmt.Errorf("writing clipboard sequence: %w", err)}
+		}
+		return nil
+	}
+}
+
+// isRowSelected checks if the row is selected
+func (r *Table) isRowSelected(row []any) bool {
+	_, ok := r.selectedRows[r.rowID(row)]
+	return ok
+}
+
+// jsonObject serializes the row as an object keyed by the column headers, fields are written
+// in the order the columns are displayed and duplicate headers are all kept
+func (r *Table) jsonObject(row []any) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for n, i := range r.columnView {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(r.columnHeaders[i])
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(jsonValue(r.cellValue(row, i)))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// serializeRows writes header and records as TSV or CSV
+func serializeRows(headers []string, records [][]string, format CopyFormat) (string, error) {
+	if format == CopyFormatCSV {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if headers != nil {
+			if err := w.Write(headers); err != nil {
+				return "", err
+			}
+		}
+		if err := w.WriteAll(records); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	var lines []string
+	if headers != nil {
+		var escaped []string
+		for _, h := range headers {
+			escaped = append(escaped, tsvEscape(h))
+		}
+		lines = append(lines, strings.Join(escaped, "\t"))
+	}
+	for _, record := range records {
+		var escaped []string
+		for _, value := range record {
+			escaped = append(escaped, tsvEscape(value))
+		}
+		lines = append(lines, strings.Join(escaped, "\t"))
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// copyString returns the visible text of the value, styling is not copied
+func copyString(value any) string {
+	return ansi.Strip(getStringFromOrdered(value))
+}
+
+// jsonValue keeps numbers as numbers and strips styling from the strings
+func jsonValue(value any) any {
+	if s, ok := value.(string); ok {
+		return ansi.Strip(s)
+	}
+	return value
+}
+
+// tsvEscape replaces the characters that would break the TSV structure
+func tsvEscape(s string) string {
+	return strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
@@ -0,0 +1,192 @@
+package table
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// decodeClipboard returns the payload of the OSC 52 sequence
+func decodeClipboard(t *testing.T, sequence string) string {
+	t.Helper()
+	const prefix = "\x1b]52;c;"
+	if !strings.HasPrefix(sequence, prefix) || !strings.HasSuffix(sequence, "\a") {
+		t.Fatalf("not an OSC 52 sequence: %q", sequence)
+	}
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(sequence, prefix), "\a"))
+	if err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	return string(payload)
+}
+
+func newClipboardTable(t *testing.T, out io.Writer) *Table {
+	t.Helper()
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	table := NewTable(60, 10, []string{"name", "note", "count"}).
+		MustSetTypes("", "", 0).
+		MustAddRows([][]any{
+			{"ada", "tab\there", 3},
+			{"bob", `say "hi", bye`, 12},
+		}).
+		SetClipboardOutput(out)
+	// cursor on the second row and column
+	table.CursorDown().CursorRight()
+	return table
+}
+
+func TestCopy(t *testing.T) {
+	tests := []struct {
+		name   string
+		format CopyFormat
+		copy   func(*Table, CopyFormat) error
+		want   string
+	}{
+		{"cell tsv", CopyFormatTSV, copyCell, `say "hi", bye`},
+		{"cell csv", CopyFormatCSV, copyCell, `"say ""hi"", bye"`},
+		{"cell json", CopyFormatJSON, copyCell, `"say \"hi\", bye"`},
+		{"row tsv", CopyFormatTSV, copyRow, "bob\tsay \"hi\", bye\t12\n"},
+		{"row csv", CopyFormatCSV, copyRow, "bob,\"say \"\"hi\"\", bye\",12\n"},
+		{"row json", CopyFormatJSON, copyRow, `{"name":"bob","note":"say \"hi\", bye","count":12}`},
+		{"view tsv", CopyFormatTSV, copyView, "name\tnote\tcount\nada\ttab here\t3\nbob\tsay \"hi\", bye\t12\n"},
+		{"view json", CopyFormatJSON, copyView,
+			`[{"name":"ada","note":"tab\there","count":3},{"name":"bob","note":"say \"hi\", bye","count":12}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			table := newClipboardTable(t, &out)
+			if err := tt.copy(table, tt.format); err != nil {
+				t.Fatalf("copy: %v", err)
+			}
+			if got := decodeClipboard(t, out.String()); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyNeedsTrackedAxis(t *testing.T) {
+	tests := []struct {
+		mode        CursorMode
+		cellErr     bool
+		rowErr      bool
+		cursorValue string
+	}{
+		{CursorModeCell, false, false, `say "hi", bye`},
+		{CursorModeRow, true, false, ""},
+		{CursorModeColumn, true, true, ""},
+		{CursorModeNone, true, true, ""},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		table := newClipboardTable(t, &out).SetCursorMode(tt.mode)
+		if err := copyCell(table, CopyFormatTSV); (err != nil) != tt.cellErr {
+			t.Errorf("mode %d: cell copy error %v, want error %v", tt.mode, err, tt.cellErr)
+		}
+		if err := copyRow(table, CopyFormatTSV); (err != nil) != tt.rowErr {
+			t.Errorf("mode %d: row copy error %v, want error %v", tt.mode, err, tt.rowErr)
+		}
+		if got := table.GetCursorValue(); got != tt.cursorValue {
+			t.Errorf("mode %d: cursor value %q, want %q", tt.mode, got, tt.cursorValue)
+		}
+	}
+}
+
+func TestCopyJSONKeepsColumnOrder(t *testing.T) {
+	var out bytes.Buffer
+	table, err := newClipboardTable(t, &out).MoveColumn(2, 0)
+	if err != nil {
+		t.Fatalf("move column: %v", err)
+	}
+	if err := copyRow(table, CopyFormatJSON); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	want := `{"count":12,"name":"bob","note":"say \"hi\", bye"}`
+	if got := decodeClipboard(t, out.String()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCopyJSONKeepsDuplicateHeaders(t *testing.T) {
+	var out bytes.Buffer
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	table := NewTable(40, 10, []string{"id", "id"}).
+		MustAddRows([][]any{{"a", "b"}}).
+		SetClipboardOutput(&out)
+	if err := copyRow(table, CopyFormatJSON); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if got, want := decodeClipboard(t, out.String()), `{"id":"a","id":"b"}`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCopyCommandWritesSequence(t *testing.T) {
+	var out bytes.Buffer
+	table := newClipboardTable(t, &out)
+	cmd, err := table.CopyCell(CopyFormatTSV)
+	if err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatal("sequence written before the command ran")
+	}
+	if msg := cmd(); msg != nil {
+		t.Fatalf("command returned %v", msg)
+	}
+	if got := decodeClipboard(t, out.String()); got != `say "hi", bye` {
+		t.Errorf("got %q", got)
+	}
+	if strings.Contains(table.Render(), "\x1b]52;") {
+		t.Error("sequence emitted with the render")
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestCopyCommandReportsWriteError(t *testing.T) {
+	table := newClipboardTable(t, failingWriter{})
+	cmd, err := table.CopyCell(CopyFormatTSV)
+	if err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if msg, ok := cmd().(ClipboardErrorMsg); !ok || !errors.Is(msg.Err, io.ErrClosedPipe) {
+		t.Errorf("command returned %v, want ClipboardErrorMsg", msg)
+	}
+}
+
+// copyCell, copyRow and copyView copy and run the command writing the sequence
+func copyCell(table *Table, format CopyFormat) error {
+	return runCopy(table.CopyCell(format))
+}
+
+func copyRow(table *Table, format CopyFormat) error {
+	return runCopy(table.CopyRow(format))
+}
+
+func copyView(table *Table, format CopyFormat) error {
+	return runCopy(table.CopyView(format))
+}
+
+func runCopy(cmd tea.Cmd, err error) error {
+	if err != nil {
+		return err
+	}
+	if msg, ok := cmd().(ClipboardErrorMsg); ok {
+		return msg.Err
+	}
+	return nil
+}
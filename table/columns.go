@@ -0,0 +1,131 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+)
+
+// HideColumn hides the column with index n, hidden columns are skipped when rendering, copying and
+// navigating, but rows still hold their values so they can be sorted and filtered by
+func (r *Table) HideColumn(index int) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	if r.columnHidden[index] {
+		return r, nil
+	}
+	return r, r.recordView("", r.setColumnHidden(index, false), r.setColumnHidden(index, true))
+}
+
+// ShowColumn shows the previously hidden column with index n
+func (r *Table) ShowColumn(index int) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	if !r.columnHidden[index] {
+		return r, nil
+	}
+	return r, r.recordView("", r.setColumnHidden(index, true), r.setColumnHidden(index, false))
+}
+
+// IsColumnHidden reports whether the column with index n is hidden
+func (r *Table) IsColumnHidden(index int) bool {
+	if index < 0 || index >= len(r.columnHidden) {
+		return false
+	}
+	return r.columnHidden[index]
+}
+
+// MoveColumn moves the column with index n to the given display position, positions count
+// hidden columns as well so a column keeps its place when it is shown again
+func (r *Table) MoveColumn(index, position int) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	if position < 0 || position >= len(r.columnOrder) {
+		message := fmt.Sprintf("column position %d out of range[%d]", position, len(r.columnOrder))
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	var order []int
+	for _, i := range r.columnOrder {
+		if i != index {
+			order = append(order, i)
+		}
+	}
+	order = append(order[:position], append([]int{index}, order[position:]...)...)
+	if slices.Equal(order, r.columnOrder) {
+		return r, nil
+	}
+	return r, r.recordView("", r.setColumnOrder(r.GetColumnOrder()), r.setColumnOrder(order))
+}
+
+// GetColumnOrder returns the display order of the columns as indexes of the headers, hidden columns included
+func (r *Table) GetColumnOrder() []int {
+	return append([]int(nil), r.columnOrder...)
+}
+
+// setColumnHidden returns the function hiding or showing the column, used to do and undo the change
+func (r *Table) setColumnHidden(index int, hidden bool) func() {
+	return func() {
+		r.columnHidden[index] = hidden
+		r.updateColumnView()
+	}
+}
+
+// setColumnOrder returns the function setting the display order of the columns, used to do and undo the change
+func (r *Table) setColumnOrder(order []int) func() {
+	return func() {
+		r.columnOrder = append([]int(nil), order...)
+		r.updateColumnView()
+	}
+}
+
+// checkColumnIndex returns an error if there is no column with index n
+func (r *Table) checkColumnIndex(index int) error {
+	if index < 0 || index >= len(r.columnHeaders) {
+		message := fmt.Sprintf("column index %d out of range[%d]", index, len(r.columnHeaders))
+		return ErrorIndexOutOfRange{msg: message}
+	}
+	return nil
+}
+
+// updateColumnView rebuilds the displayed columns from the order and hidden flags,
+// cursor stays on the same column if it is still displayed
+func (r *Table) updateColumnView() {
+	cursorColumn := r.cursorColumn()
+
+	r.columnView = r.columnView[:0]
+	for _, i := range r.columnOrder {
+		if !r.columnHidden[i] {
+			r.columnView = append(r.columnView, i)
+		}
+	}
+
+	if position := r.columnPosition(cursorColumn); position >= 0 {
+		r.cursorIndexX = position
+	} else if r.cursorIndexX >= len(r.columnView) {
+		r.cursorIndexX = len(r.columnView) - 1
+	}
+	if r.cursorIndexX < 0 {
+		r.cursorIndexX = 0
+	}
+	r.recalculateVisibleColumnRange()
+}
+
+// cursorColumn returns the index of the column under the cursor, -1 if no column is displayed
+func (r *Table) cursorColumn() int {
+	if r.cursorIndexX < 0 || r.cursorIndexX >= len(r.columnView) {
+		return -1
+	}
+	return r.columnView[r.cursorIndexX]
+}
+
+// columnPosition returns the display position of the column with index n, -1 if it is not displayed
+func (r *Table) columnPosition(index int) int {
+	for position, i := range r.columnView {
+		if i == index {
+			return position
+		}
+	}
+	return -1
+}
@@ -0,0 +1,180 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ComputedFunc derives the value of a computed column from the row, the row holds the values of
+// the regular columns only and must not be modified, the result has to be of the column type or nil.
+// Results of other types are replaced by nil and reported by GetComputedError
+type ComputedFunc func(row []any) any
+
+// computedColumn is a virtual column that is not part of the rows, its values are derived from them
+type computedColumn struct {
+	columnType any
+	fn         ComputedFunc
+}
+
+// cellKey identifies a cell, row by its rowID and column by its index
+type cellKey struct {
+	row    uint64
+	column int
+}
+
+// AddComputedColumn adds a virtual column with the header, its values are derived from the other
+// cells of the row by the function. Values are evaluated lazily when the column is rendered, sorted,
+// filtered or copied and cached until the row is changed with UpdateRow.
+// Computed columns are placed after the regular ones, so rows added to the table do not hold them.
+func (r *Table) AddComputedColumn(header string, columnType any, fn ComputedFunc) (*Table, error) {
+	if !isOrdered(columnType) {
+		message := fmt.Sprintf("computed column of type %v is not of type Ordered", reflect.TypeOf(columnType))
+		return r, ErrorBadType{msg: message}
+	}
+	if fn == nil {
+		message := fmt.Sprintf("computed column %q has no function", header)
+		return r, ErrorBadValue{msg: message}
+	}
+	index := len(r.columnHeaders)
+	r.columnHeaders = append(r.columnHeaders, header)
+	r.columnRatio = append(r.columnRatio, 1)
+	r.columnMinWidth = append(r.columnMinWidth, 0)
+	r.columnWidth = append(r.columnWidth, 0)
+	r.columnOrder = append(r.columnOrder, index)
+	r.columnHidden = append(r.columnHidden, false)
+	r.computedColumns = append(r.computedColumns, computedColumn{columnType: columnType, fn: fn})
+	r.updateColumnView()
+	return r, nil
+}
+
+// MustAddComputedColumn executes AddComputedColumn and panics if there is an error
+func (r *Table) MustAddComputedColumn(header string, columnType any, fn ComputedFunc) *Table {
+	if _, err := r.AddComputedColumn(header, columnType, fn); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// IsColumnComputed reports whether the column with index n is a computed column
+func (r *Table) IsColumnComputed(index int) bool {
+	return index >= len(r.columnType) && index < len(r.columnHeaders)
+}
+
+// UpdateRow replaces the values of the row with index n, index is the position within the filtered
+// rows as the cursor y, the row keeps its selection and its computed values are evaluated again.
+// Rows are not sorted again, so the row stays in place until the next sort, the change can be undone
+func (r *Table) UpdateRow(index int, row []any) (*Table, error) {
+	if index < 0 || index >= len(r.filteredRows) {
+		message := fmt.Sprintf("row index %d out of range[%d]", index, len(r.filteredRows))
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	if err := r.validateRow(row...); err != nil {
+		return r, err
+	}
+	target := r.filteredRows[index]
+	previous := append([]any(nil), target...)
+	values := append([]any(nil), row...)
+	entry := historyEntry{
+		kind:   OperationUpdateRow,
+		row:    target,
+		column: -1,
+		undo:   func() { r.setRowValues(target, previous) },
+		redo:   func() { r.setRowValues(target, values) },
+	}
+	entry.redo()
+	return r, r.record(entry)
+}
+
+// MustUpdateRow executes UpdateRow and panics if there is an error
+func (r *Table) MustUpdateRow(index int, row []any) *Table {
+	if _, err := r.UpdateRow(index, row); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// columnTypeAt returns the type of the column with index n, regular or computed
+func (r *Table) columnTypeAt(index int) any {
+	if index < len(r.columnType) {
+		return r.columnType[index]
+	}
+	if column := index - len(r.columnType); column < len(r.computedColumns) {
+		return r.computedColumns[column].columnType
+	}
+	return nil
+}
+
+// cellValue returns the value of the column with index n in the row as it is sorted and filtered,
+// cells of the columns with a renderer are replaced by their scalar
+func (r *Table) cellValue(row []any, index int) any {
+	value := r.rawCellValue(row, index)
+	if renderer, ok := r.columnRenderers[index]; ok && value != nil {
+		value, _ = renderer.Scalar(value)
+	}
+	return value
+}
+
+// rawCellValue returns the value of the column with index n in the row,
+// computed columns are evaluated on first access and cached
+func (r *Table) rawCellValue(row []any, index int) any {
+	if index < len(row) {
+		return row[index]
+	}
+	column := index - len(r.columnType)
+	if column < 0 || column >= len(r.computedColumns) {
+		return nil
+	}
+	key := cellKey{row: r.rowID(row), column: column}
+	if value, ok := r.computedValues[key]; ok {
+		return value
+	}
+	if r.computedValues == nil {
+		r.computedValues = make(map[cellKey]any)
+	}
+	value := r.computedColumns[column].fn(row)
+	if err := r.validateComputedValue(value, index); err != nil {
+		r.computedErr = err
+		value = nil
+	}
+	r.computedValues[key] = value
+	return value
+}
+
+// validateComputedValue checks the result of the computed column with index n against the column type,
+// values drawn by a renderer are checked by it
+func (r *Table) validateComputedValue(value any, index int) error {
+	if value == nil {
+		return nil
+	}
+	if renderer, ok := r.columnRenderers[index]; ok {
+		return r.validateRenderedCell(renderer, value, index)
+	}
+	if columnType := r.columnTypeAt(index); reflect.TypeOf(value) != reflect.TypeOf(columnType) {
+		message := fmt.Sprintf(
+			"type of the computed value[%v] on index %d not matching type of the column[%v]",
+			reflect.TypeOf(value), index, reflect.TypeOf(columnType),
+		)
+		return ErrorBadCellType{msg: message}
+	}
+	return nil
+}
+
+// GetComputedError returns the error of the last computed value that did not match its column type
+// and was replaced by nil, nil if there is none
+func (r *Table) GetComputedError() error {
+	return r.computedErr
+}
+
+// invalidateComputed drops the cached computed values of the row
+func (r *Table) invalidateComputed(row []any) {
+	key := r.rowID(row)
+	for column := range r.computedColumns {
+		delete(r.computedValues, cellKey{row: key, column: column})
+	}
+}
+
+// resetComputed drops all the cached computed values, used when all the rows are removed
+func (r *Table) resetComputed() {
+	r.computedValues = nil
+	r.computedErr = nil
+}
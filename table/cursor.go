@@ -2,6 +2,30 @@ package table
 
 // TODO: create cursor struct that holds the cursor position and direction
 
+// CursorMode controls how the cursor is rendered and how it moves around the table
+type CursorMode int
+
+const (
+	// CursorModeCell highlights the row and the cell under the cursor, this is the default
+	CursorModeCell CursorMode = iota
+	// CursorModeRow highlights only the row under the cursor, moving left or right scrolls the columns
+	CursorModeRow
+	// CursorModeColumn highlights the whole column under the cursor, moving up or down scrolls the rows
+	CursorModeColumn
+	// CursorModeNone renders no cursor, the table is a passive read-only display that only scrolls
+	CursorModeNone
+)
+
+// tracksRow reports whether the cursor selects a row in this mode
+func (mode CursorMode) tracksRow() bool {
+	return mode == CursorModeCell || mode == CursorModeRow
+}
+
+// tracksColumn reports whether the cursor selects a column in this mode
+func (mode CursorMode) tracksColumn() bool {
+	return mode == CursorModeCell || mode == CursorModeColumn
+}
+
 // cursorDirection indicates the direction of the cursor movement, it starts at the up left direction
 type cursorDirection uint8
 
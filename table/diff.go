@@ -0,0 +1,229 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tableDefaultHighlightDuration is how long the changed cells stay highlighted after a refresh
+const tableDefaultHighlightDuration = 2 * time.Second
+
+// cellChangeDirection tells how the value of the changed cell moved
+type cellChangeDirection int
+
+const (
+	cellChanged cellChangeDirection = iota
+	cellIncreased
+	cellDecreased
+)
+
+// cellChange is a cell changed by RefreshRows
+type cellChange struct {
+	direction cellChangeDirection
+	at        time.Time
+}
+
+// HighlightTickMsg expires the highlights of the changed cells, it has to be passed to Table.Update
+type HighlightTickMsg struct {
+	table *Table
+	id    int
+}
+
+// SetKeyColumn sets the column identifying the rows, RefreshRows matches the rows by its values,
+// so they have to be unique
+func (r *Table) SetKeyColumn(index int) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	r.keyColumn = index
+	return r, nil
+}
+
+// MustSetKeyColumn executes SetKeyColumn and panics if there is an error
+func (r *Table) MustSetKeyColumn(index int) *Table {
+	if _, err := r.SetKeyColumn(index); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// SetHighlightDuration sets how long the cells changed by RefreshRows stay highlighted, expiry is driven
+// by tea.Tick, 0 turns the ticking off and the cells stay highlighted until the next refresh,
+// negative value turns the highlighting off
+func (r *Table) SetHighlightDuration(value time.Duration) *Table {
+	r.highlightDuration = value
+	if value < 0 {
+		r.cellChanges = nil
+	}
+	r.setRowsUpdate()
+	return r
+}
+
+// RefreshRows replaces the rows with the new snapshot of the data matching them by the key column,
+// matched rows are updated in place and keep their position, selection and cursor, new rows are added
+// and rows missing from the snapshot are removed. Changed cells are highlighted, numeric cells with
+// StyleKeyCellIncreased or StyleKeyCellDecreased and the others with StyleKeyCellChanged.
+// Key column values have to be unique in both the rows of the table and the snapshot, otherwise an error
+// is returned and nothing changes. Refresh can not be undone and it clears the history.
+// Returned command expires the highlights, it is nil when there is nothing to expire.
+func (r *Table) RefreshRows(rows [][]any) (tea.Cmd, error) {
+	if r.keyColumn < 0 || r.IsColumnComputed(r.keyColumn) {
+		return nil, ErrorBadValue{msg: "key column has to be set to a regular column to refresh rows"}
+	}
+	for _, row := range rows {
+		if err := r.validateRow(row...); err != nil {
+			return nil, err
+		}
+	}
+	existing, err := r.rowsByKey(r.rows, "table")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.rowsByKey(rows, "snapshot"); err != nil {
+		return nil, err
+	}
+
+	// recorded operations refer to the rows as they were before the refresh
+	r.ClearHistory()
+
+	if r.highlightDuration <= 0 {
+		// highlights of the previous refresh stay until this one
+		r.cellChanges = nil
+	}
+	now := time.Now()
+	refreshed := make(map[uint64]bool, len(rows))
+	var added [][]any
+	for _, row := range rows {
+		target, ok := existing[r.facetValue(row, r.keyColumn)]
+		if !ok {
+			added = append(added, row)
+			continue
+		}
+		refreshed[r.rowID(target)] = true
+		if r.diffRow(target, row, now) {
+			copy(target, row)
+			r.invalidateComputed(target)
+		}
+	}
+
+	// rows missing from the snapshot are dropped along with everything that refers to them
+	kept := make([][]any, 0, len(r.rows))
+	for _, row := range r.rows {
+		if !refreshed[r.rowID(row)] {
+			r.forgetRow(row)
+			continue
+		}
+		kept = append(kept, row)
+	}
+	r.rows = kept
+	r.appendRows(added)
+
+	r.highlightTickID++
+	return r.highlightTick(), nil
+}
+
+// rowsByKey maps the rows by the value of the key column, returns an error if the value is not unique
+func (r *Table) rowsByKey(rows [][]any, source string) (map[string][]any, error) {
+	keyed := make(map[string][]any, len(rows))
+	for _, row := range rows {
+		key := r.facetValue(row, r.keyColumn)
+		if _, ok := keyed[key]; ok {
+			message := fmt.Sprintf("key column value %q is not unique in the %s rows", key, source)
+			return nil, ErrorBadValue{msg: message}
+		}
+		keyed[key] = row
+	}
+	return keyed, nil
+}
+
+// diffRow notes the cells of the row that differ in the new values, reports whether there is any
+func (r *Table) diffRow(row, values []any, now time.Time) bool {
+	var changed bool
+	for column := range row {
+		// rendered cells may hold values that are not comparable, e.g. slices
+		if reflect.DeepEqual(row[column], values[column]) {
+			continue
+		}
+		changed = true
+		if r.highlightDuration < 0 {
+			continue
+		}
+		direction := cellChanged
+		previous, okPrevious := toFloat(r.cellValue(row, column))
+		next, okNext := toFloat(r.cellValue(values, column))
+		if okPrevious && okNext {
+			if next > previous {
+				direction = cellIncreased
+			} else if next < previous {
+				direction = cellDecreased
+			}
+		}
+		if r.cellChanges == nil {
+			r.cellChanges = make(map[cellKey]cellChange)
+		}
+		r.cellChanges[cellKey{row: r.rowID(row), column: column}] = cellChange{direction: direction, at: now}
+	}
+	return changed
+}
+
+// forgetRow drops the bookkeeping of the row that is removed for good
+func (r *Table) forgetRow(row []any) {
+	key := r.rowID(row)
+	delete(r.selectedRows, key)
+	for column := range row {
+		delete(r.cellChanges, cellKey{row: key, column: column})
+	}
+	r.invalidateComputed(row)
+	delete(r.rowIDs, rowAddress(row))
+}
+
+// cellChangeStyle returns the style key of the highlighted cell, reports false if the cell is not highlighted
+func (r *Table) cellChangeStyle(row []any, column int) (StyleKey, bool) {
+	change, ok := r.cellChanges[cellKey{row: r.rowID(row), column: column}]
+	if !ok || (r.highlightDuration > 0 && time.Since(change.at) >= r.highlightDuration) {
+		return 0, false
+	}
+	switch change.direction {
+	case cellIncreased:
+		return StyleKeyCellIncreased, true
+	case cellDecreased:
+		return StyleKeyCellDecreased, true
+	default:
+		return StyleKeyCellChanged, true
+	}
+}
+
+// handleHighlightTick drops the expired highlights and schedules the next expiry
+func (r *Table) handleHighlightTick(msg HighlightTickMsg) tea.Cmd {
+	// a newer refresh has its own tick
+	if msg.id != r.highlightTickID {
+		return nil
+	}
+	for key, change := range r.cellChanges {
+		if time.Since(change.at) >= r.highlightDuration {
+			delete(r.cellChanges, key)
+		}
+	}
+	r.setRowsUpdate()
+	return r.highlightTick()
+}
+
+// highlightTick returns the command firing when the oldest highlight expires, nil if nothing expires
+func (r *Table) highlightTick() tea.Cmd {
+	if r.highlightDuration <= 0 || len(r.cellChanges) == 0 {
+		return nil
+	}
+	var oldest time.Time
+	for _, change := range r.cellChanges {
+		if oldest.IsZero() || change.at.Before(oldest) {
+			oldest = change.at
+		}
+	}
+	id := r.highlightTickID
+	return tea.Tick(time.Until(oldest.Add(r.highlightDuration)), func(time.Time) tea.Msg {
+		return HighlightTickMsg{table: r, id: id}
+	})
+}
@@ -0,0 +1,143 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newKeyedTable() *Table {
+	return NewTable(40, 10, []string{"id", "price", "note"}).
+		MustSetTypes("", 0, "").
+		MustAddRows([][]any{{"a", 1, "x"}, {"b", 2, "y"}, {"c", 3, "z"}}).
+		MustSetKeyColumn(0)
+}
+
+func TestRefreshRows(t *testing.T) {
+	table := newKeyedTable().SetHighlightDuration(0)
+	kept := table.rows[0]
+	table.ToggleRowSelection()
+	if _, err := table.RefreshRows([][]any{{"d", 4, "w"}, {"b", 5, "y"}, {"a", 1, "x"}, {"c", 1, "q"}}); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if got := fmt.Sprint(table.rows); got != "[[a 1 x] [b 5 y] [c 1 q] [d 4 w]]" {
+		t.Errorf("rows %s, want matched rows updated in place and the new row added", got)
+	}
+	if rowAddress(table.rows[0]) != rowAddress(kept) || !table.isRowSelected(table.rows[0]) {
+		t.Error("unchanged row lost its identity or selection")
+	}
+
+	tests := []struct {
+		name   string
+		row    int
+		column int
+		style  StyleKey
+		ok     bool
+	}{
+		{"unchanged", 0, 1, 0, false},
+		{"increased", 1, 1, StyleKeyCellIncreased, true},
+		{"decreased", 2, 1, StyleKeyCellDecreased, true},
+		{"changed text", 2, 2, StyleKeyCellChanged, true},
+		{"added row", 3, 1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style, ok := table.cellChangeStyle(table.rows[tt.row], tt.column)
+			if ok != tt.ok || style != tt.style {
+				t.Errorf("style %v, %v, want %v, %v", style, ok, tt.style, tt.ok)
+			}
+		})
+	}
+
+	if _, err := table.RefreshRows([][]any{{"a", 1, "x"}}); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if got := fmt.Sprint(table.rows); got != "[[a 1 x]]" {
+		t.Errorf("rows %s, want the rows missing from the snapshot removed", got)
+	}
+	if _, ok := table.cellChangeStyle(table.rows[0], 1); ok {
+		t.Error("highlight of the previous refresh kept without ticking")
+	}
+}
+
+func TestRefreshRowsRejectsDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing [][]any
+		snapshot [][]any
+	}{
+		{"in the snapshot", [][]any{{"a", 1, "x"}}, [][]any{{"a", 1, "x"}, {"a", 2, "y"}}},
+		{"in the table", [][]any{{"a", 1, "x"}, {"a", 2, "y"}}, [][]any{{"a", 3, "x"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(40, 10, []string{"id", "price", "note"}).
+				MustSetTypes("", 0, "").
+				MustAddRows(tt.existing).
+				MustSetKeyColumn(0)
+			before := fmt.Sprint(table.rows)
+			_, err := table.RefreshRows(tt.snapshot)
+			if !errors.As(err, &ErrorBadValue{}) {
+				t.Fatalf("error %v, want ErrorBadValue", err)
+			}
+			if got := fmt.Sprint(table.rows); got != before {
+				t.Errorf("rows %s, want them left as %s", got, before)
+			}
+		})
+	}
+}
+
+func TestRefreshRowsNeedsKeyColumn(t *testing.T) {
+	table := NewTable(40, 10, []string{"id"}).MustAddRows([][]any{{"a"}})
+	if _, err := table.RefreshRows([][]any{{"a"}}); err == nil {
+		t.Error("refresh without a key column did not fail")
+	}
+}
+
+func TestHighlightExpires(t *testing.T) {
+	table := newKeyedTable().SetHighlightDuration(20 * time.Millisecond)
+	cmd, err := table.RefreshRows([][]any{{"a", 9, "x"}, {"b", 2, "y"}, {"c", 3, "z"}})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if _, ok := table.cellChangeStyle(table.rows[0], 1); !ok {
+		t.Fatal("changed cell not highlighted")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command expiring the highlight")
+	}
+	msg := cmd()
+	table, next := table.Update(msg)
+	if _, ok := table.cellChangeStyle(table.rows[0], 1); ok {
+		t.Error("highlight not expired by the tick")
+	}
+	if next != nil || len(table.cellChanges) != 0 {
+		t.Errorf("tick scheduled again with %d highlights left", len(table.cellChanges))
+	}
+
+	// tick of an older refresh does nothing
+	if _, err := table.RefreshRows([][]any{{"a", 1, "x"}, {"b", 2, "y"}, {"c", 3, "z"}}); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if _, next := table.Update(msg); next != nil {
+		t.Error("stale tick scheduled another one")
+	}
+	if _, ok := table.cellChangeStyle(table.rows[0], 1); !ok {
+		t.Error("stale tick expired the highlight of the newer refresh")
+	}
+}
+
+func TestHighlightingOff(t *testing.T) {
+	table := newKeyedTable().SetHighlightDuration(-1)
+	cmd, err := table.RefreshRows([][]any{{"a", 9, "x"}, {"b", 2, "y"}, {"c", 3, "z"}})
+	if err != nil || cmd != nil {
+		t.Fatalf("refresh returned %v, %v, want no command", cmd, err)
+	}
+	if table.rows[0][1] != 9 {
+		t.Errorf("value %v, want the row updated", table.rows[0][1])
+	}
+	if _, ok := table.cellChangeStyle(table.rows[0], 1); ok {
+		t.Error("cell highlighted with highlighting off")
+	}
+}
@@ -1,5 +1,8 @@
 package table
 
+// Errors of the table package are typed, they can be matched with errors.As to read the
+// message or with errors.Is against the zero value, e.g. errors.Is(err, ErrorRowLen{})
+
 // ErrorBadType type does not match Ordered interface types
 type ErrorBadType struct {
 	msg string
@@ -9,6 +12,12 @@ func (e ErrorBadType) Error() string {
 	return e.msg
 }
 
+// Is reports whether target is an ErrorBadType
+func (e ErrorBadType) Is(target error) bool {
+	_, ok := target.(ErrorBadType)
+	return ok
+}
+
 // ErrorRowLen row length is not matching headers len
 type ErrorRowLen struct {
 	msg string
@@ -18,6 +27,12 @@ func (e ErrorRowLen) Error() string {
 	return e.msg
 }
 
+// Is reports whether target is an ErrorRowLen
+func (e ErrorRowLen) Is(target error) bool {
+	_, ok := target.(ErrorRowLen)
+	return ok
+}
+
 // ErrorBadCellType type of cell does not match type of column
 type ErrorBadCellType struct {
 	msg string
@@ -26,3 +41,54 @@ type ErrorBadCellType struct {
 func (e ErrorBadCellType) Error() string {
 	return e.msg
 }
+
+// Is reports whether target is an ErrorBadCellType
+func (e ErrorBadCellType) Is(target error) bool {
+	_, ok := target.(ErrorBadCellType)
+	return ok
+}
+
+// ErrorColumnsLen per column slice length is not matching the number of columns
+type ErrorColumnsLen struct {
+	msg string
+}
+
+func (e ErrorColumnsLen) Error() string {
+	return e.msg
+}
+
+// Is reports whether target is an ErrorColumnsLen
+func (e ErrorColumnsLen) Is(target error) bool {
+	_, ok := target.(ErrorColumnsLen)
+	return ok
+}
+
+// ErrorBadValue value is outside the range accepted by the setter
+type ErrorBadValue struct {
+	msg string
+}
+
+func (e ErrorBadValue) Error() string {
+	return e.msg
+}
+
+// Is reports whether target is an ErrorBadValue
+func (e ErrorBadValue) Is(target error) bool {
+	_, ok := target.(ErrorBadValue)
+	return ok
+}
+
+// ErrorIndexOutOfRange column or row index does not exist
+type ErrorIndexOutOfRange struct {
+	msg string
+}
+
+func (e ErrorIndexOutOfRange) Error() string {
+	return e.msg
+}
+
+// Is reports whether target is an ErrorIndexOutOfRange
+func (e ErrorIndexOutOfRange) Is(target error) bool {
+	_, ok := target.(ErrorIndexOutOfRange)
+	return ok
+}
@@ -0,0 +1,460 @@
+package table
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// tableDefaultFacetLimit number of the most frequent values listed in the facet panel
+	tableDefaultFacetLimit = 10
+	// tableFacetPanelChrome lines of the facet panel that are not entries, border, title, search and more
+	tableFacetPanelChrome = 5
+)
+
+// Facet is a distinct value of the column with the number of rows holding it
+type Facet struct {
+	Value string
+	Count int
+	// Selected marks the values that are part of the value filter of the column
+	Selected bool
+}
+
+// FacetKeyMap keys handled by the facet panel while it is open, matched against tea.KeyMsg.String(),
+// any other printable key is typed into the search box. Space toggles only while the search box is empty,
+// once there is a search it is typed into it
+type FacetKeyMap struct {
+	Up     []string
+	Down   []string
+	Toggle []string
+	Clear  []string
+	Close  []string
+}
+
+// DefaultFacetKeyMap returns the default facet panel key map
+func DefaultFacetKeyMap() FacetKeyMap {
+	return FacetKeyMap{
+		Up:     []string{"up", "ctrl+p"},
+		Down:   []string{"down", "ctrl+n"},
+		Toggle: []string{" ", "enter"},
+		Clear:  []string{"delete"},
+		Close:  []string{"esc"},
+	}
+}
+
+// facetPanel state of the open facet panel
+type facetPanel struct {
+	column int
+	search string
+	cursor int
+	// width and height of the last render, used to map mouse events onto the entries
+	width  int
+	height int
+}
+
+// SetValueFilter filters the column with index n to the rows holding exactly one of the values,
+// values are compared to the visible text of the cell, no values remove the filter of the column.
+// Value filters are combined with each other and with SetFilter
+func (r *Table) SetValueFilter(index int, values ...string) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	var set map[string]struct{}
+	if len(values) > 0 {
+		set = make(map[string]struct{}, len(values))
+		for _, value := range values {
+			set[value] = struct{}{}
+		}
+	}
+	previous := r.valueFilters[index]
+	if maps.Equal(previous, set) {
+		return r, nil
+	}
+	return r, r.recordView("", r.setValueFilter(index, previous), r.setValueFilter(index, set))
+}
+
+// MustSetValueFilter executes SetValueFilter and panics if there is an error
+func (r *Table) MustSetValueFilter(index int, values ...string) *Table {
+	if _, err := r.SetValueFilter(index, values...); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ToggleValueFilter adds the value to the value filter of the column with index n, or removes it if it is there
+func (r *Table) ToggleValueFilter(index int, value string) (*Table, error) {
+	values := r.GetValueFilter(index)
+	toggled := values[:0]
+	for _, v := range values {
+		if v != value {
+			toggled = append(toggled, v)
+		}
+	}
+	if len(toggled) == len(values) {
+		toggled = append(toggled, value)
+	}
+	return r.SetValueFilter(index, toggled...)
+}
+
+// GetValueFilter returns the sorted values of the value filter of the column with index n
+func (r *Table) GetValueFilter(index int) []string {
+	var values []string
+	for value := range r.valueFilters[index] {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// UnsetValueFilters removes value filters from all the columns
+func (r *Table) UnsetValueFilters() *Table {
+	if len(r.valueFilters) == 0 {
+		return r
+	}
+	previous := maps.Clone(r.valueFilters)
+	// unset can not fail, hook can only revert it
+	_ = r.recordView("", r.setValueFilters(previous), r.setValueFilters(nil))
+	return r
+}
+
+// setValueFilter returns the function setting the values of the value filter of the column with index n,
+// nil removes the filter. Used to do and undo the change, sets are never modified once set so they can be
+// shared with the history
+func (r *Table) setValueFilter(index int, set map[string]struct{}) func() {
+	return func() {
+		if set == nil {
+			delete(r.valueFilters, index)
+		} else {
+			if r.valueFilters == nil {
+				r.valueFilters = make(map[int]map[string]struct{})
+			}
+			r.valueFilters[index] = set
+		}
+		r.refreshValueFilters()
+	}
+}
+
+// setValueFilters returns the function replacing the value filters of all the columns, used to do and undo the change
+func (r *Table) setValueFilters(filters map[int]map[string]struct{}) func() {
+	return func() {
+		r.valueFilters = maps.Clone(filters)
+		r.refreshValueFilters()
+	}
+}
+
+// refreshValueFilters filters the rows again after the value filters changed
+func (r *Table) refreshValueFilters() {
+	r.applyFilter()
+	r.setTopRow()
+	r.setRowsUpdate()
+	r.setHeadersUpdate()
+}
+
+// Facets returns the distinct values of the column with index n and the number of rows holding them,
+// most frequent first. Rows are counted after filtering, except for the value filter of the column
+// itself so the values that are not selected can still be listed. Facets are cached until the rows
+// or the filters change
+func (r *Table) Facets(index int) ([]Facet, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return nil, err
+	}
+	if facets, ok := r.facets[index]; ok {
+		return append([]Facet(nil), facets...), nil
+	}
+	counts := make(map[string]int)
+	for _, row := range r.rows {
+		if r.rowMatches(row, index) {
+			counts[r.facetValue(row, index)]++
+		}
+	}
+	// selected values are listed even when no row holds them, so they can be deselected
+	for value := range r.valueFilters[index] {
+		if _, ok := counts[value]; !ok {
+			counts[value] = 0
+		}
+	}
+	facets := make([]Facet, 0, len(counts))
+	for value, count := range counts {
+		_, selected := r.valueFilters[index][value]
+		facets = append(facets, Facet{Value: value, Count: count, Selected: selected})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	if r.facets == nil {
+		r.facets = make(map[int][]Facet)
+	}
+	r.facets[index] = facets
+	return append([]Facet(nil), facets...), nil
+}
+
+// resetFacets drops the cached facets
+func (r *Table) resetFacets() {
+	r.facets = nil
+}
+
+// SetFacetLimit sets the number of the most frequent values listed in the facet panel, values
+// outside of it can be reached with the search box, values lower than 1 reset it to default
+func (r *Table) SetFacetLimit(value int) *Table {
+	if value < 1 {
+		value = tableDefaultFacetLimit
+	}
+	r.facetLimit = value
+	return r
+}
+
+// SetFacetKeyMap replaces the keys handled by the facet panel
+func (r *Table) SetFacetKeyMap(keyMap FacetKeyMap) *Table {
+	r.facetKeyMap = keyMap
+	return r
+}
+
+// OpenFacetPanel opens the facet panel of the column with index n over the rows, while it is open
+// Update handles tea.KeyMsg and tea.MouseMsg for the panel, selecting the values toggles the value filter
+func (r *Table) OpenFacetPanel(index int) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	r.facetPanel = &facetPanel{column: index}
+	return r, nil
+}
+
+// CloseFacetPanel closes the facet panel, value filters set with it stay applied
+func (r *Table) CloseFacetPanel() *Table {
+	r.facetPanel = nil
+	r.setRowsUpdate()
+	return r
+}
+
+// IsFacetPanelOpen reports whether the facet panel is open
+func (r *Table) IsFacetPanelOpen() bool {
+	return r.facetPanel != nil
+}
+
+// rowMatches reports whether the row passes the filters, value filter of the skipped column is not applied
+func (r *Table) rowMatches(row []any, skipColumn int) bool {
+	if r.filterString != "" {
+		// styled cells are matched on their visible text only
+		cellValue := ansi.Strip(getStringFromOrdered(r.cellValue(row, r.filteredColumn)))
+		// convert to lower, not sure if anybody needs case-sensitive filtering
+		// if you are reading this and need it, open up an issue :zap:
+		if !strings.Contains(strings.ToLower(cellValue), strings.ToLower(r.filterString)) {
+			return false
+		}
+	}
+	for index, filter := range r.nullFilters {
+		if !matchesNullFilter(r.cellValue(row, index), filter) {
+			return false
+		}
+	}
+	for index, values := range r.valueFilters {
+		if index == skipColumn {
+			continue
+		}
+		if _, ok := values[r.facetValue(row, index)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// facetValue returns the value of the cell as it is compared by the value filter,
+// null cells are represented by an empty string
+func (r *Table) facetValue(row []any, index int) string {
+	return ansi.Strip(getStringFromOrdered(r.cellValue(row, index)))
+}
+
+// facetEntries returns the facets listed in the panel and the number of the matching facets left out
+func (r *Table) facetEntries() ([]Facet, int) {
+	facets, err := r.Facets(r.facetPanel.column)
+	if err != nil {
+		return nil, 0
+	}
+	var matching []Facet
+	search := strings.ToLower(r.facetPanel.search)
+	for _, facet := range facets {
+		if strings.Contains(strings.ToLower(facet.Value), search) {
+			matching = append(matching, facet)
+		}
+	}
+	limit := r.facetLimit
+	if limit < 1 {
+		limit = tableDefaultFacetLimit
+	}
+	// panel has to fit the rows box
+	if fit := r.rowsBoxHeight - tableFacetPanelChrome; limit > fit {
+		limit = int(math.Max(1, float64(fit)))
+	}
+	if len(matching) <= limit {
+		return matching, 0
+	}
+	return matching[:limit], len(matching) - limit
+}
+
+// moveFacetCursor moves the panel cursor by n entries, it stays within the listed entries
+func (r *Table) moveFacetCursor(n int) {
+	entries, _ := r.facetEntries()
+	r.facetPanel.cursor = clamp(r.facetPanel.cursor+n, 0, len(entries)-1)
+}
+
+// toggleFacetEntry toggles the value of the listed entry with index n in the value filter
+func (r *Table) toggleFacetEntry(index int) {
+	entries, _ := r.facetEntries()
+	if index < 0 || index >= len(entries) {
+		return
+	}
+	r.facetPanel.cursor = index
+	_, _ = r.ToggleValueFilter(r.facetPanel.column, entries[index].Value)
+}
+
+// handleFacetKey handles the keys while the facet panel is open
+func (r *Table) handleFacetKey(msg tea.KeyMsg) {
+	key := msg.String()
+	switch {
+	case keyMatches(key, r.facetKeyMap.Close):
+		r.CloseFacetPanel()
+	case msg.Type == tea.KeySpace && r.facetPanel.search != "":
+		r.facetPanel.search += " "
+		r.facetPanel.cursor = 0
+	case keyMatches(key, r.facetKeyMap.Up):
+		r.moveFacetCursor(-1)
+	case keyMatches(key, r.facetKeyMap.Down):
+		r.moveFacetCursor(1)
+	case keyMatches(key, r.facetKeyMap.Toggle):
+		r.toggleFacetEntry(r.facetPanel.cursor)
+	case keyMatches(key, r.facetKeyMap.Clear):
+		_, _ = r.SetValueFilter(r.facetPanel.column)
+	case msg.Type == tea.KeyBackspace:
+		if search := []rune(r.facetPanel.search); len(search) > 0 {
+			r.facetPanel.search = string(search[:len(search)-1])
+			r.facetPanel.cursor = 0
+		}
+	case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+		r.facetPanel.search += string(msg.Runes)
+		r.facetPanel.cursor = 0
+	}
+}
+
+// handleFacetMouse handles the mouse while the facet panel is open, clicking an entry toggles it
+// and clicking outside the panel closes it
+func (r *Table) handleFacetMouse(msg tea.MouseMsg) {
+	if msg.Action != tea.MouseActionPress {
+		return
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		r.moveFacetCursor(-1)
+		return
+	case tea.MouseButtonWheelDown:
+		r.moveFacetCursor(1)
+		return
+	case tea.MouseButtonLeft:
+	default:
+		return
+	}
+	left, top := r.facetPanelOrigin()
+	x, y := msg.X-r.originX-left, msg.Y-r.originY-top
+	if x < 0 || y < 0 || x >= r.facetPanel.width || y >= r.facetPanel.height {
+		r.CloseFacetPanel()
+		return
+	}
+	// entries follow the top border, the title and the search box
+	r.toggleFacetEntry(y - 3)
+}
+
+// facetPanelOrigin returns the position of the panel relative to the table, it is centered
+// over the rows box the same way lipgloss.Place centers it
+func (r *Table) facetPanelOrigin() (int, int) {
+	center := func(size, content int) int {
+		gap := size - content
+		if gap <= 0 {
+			return 0
+		}
+		return gap - int(math.Round(float64(gap)*float64(lipgloss.Center)))
+	}
+	// header takes the first line
+	return center(r.width, r.facetPanel.width), 1 + center(r.rowsBox.GetHeight(), r.facetPanel.height)
+}
+
+// renderFacetPanel renders the facet panel placed over the rows box
+func (r *Table) renderFacetPanel() string {
+	entries, more := r.facetEntries()
+	r.facetPanel.cursor = clamp(r.facetPanel.cursor, 0, len(entries)-1)
+
+	countWidth := 1
+	for _, facet := range entries {
+		countWidth = int(math.Max(float64(countWidth), float64(len(strconv.Itoa(facet.Count)))))
+	}
+	title := r.columnHeaders[r.facetPanel.column]
+	search := "/ " + r.facetPanel.search + "▏"
+	var footer string
+	if more > 0 {
+		footer = fmt.Sprintf("%s %d more", r.ellipsis, more)
+	} else if len(entries) == 0 {
+		footer = "no values"
+	}
+	// null cells are listed under the empty value
+	label := func(facet Facet) string {
+		if facet.Value == "" {
+			return r.nullDisplay
+		}
+		return facet.Value
+	}
+	innerWidth := 0
+	for _, line := range []string{title, search, footer} {
+		innerWidth = int(math.Max(float64(innerWidth), float64(ansi.StringWidth(line))))
+	}
+	for _, facet := range entries {
+		innerWidth = int(math.Max(float64(innerWidth), float64(4+ansi.StringWidth(label(facet))+1+countWidth)))
+	}
+	// the panel has border and padding on both sides
+	innerWidth = int(math.Max(1, math.Min(float64(innerWidth), float64(r.width-4))))
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(ansi.Truncate(title, innerWidth, r.ellipsis)),
+		ansi.Truncate(search, innerWidth, r.ellipsis),
+	}
+	valueWidth := int(math.Max(0, float64(innerWidth-4-1-countWidth)))
+	for i, facet := range entries {
+		check := "[ ] "
+		if facet.Selected {
+			check = "[x] "
+		}
+		value := ansi.Truncate(label(facet), valueWidth, r.ellipsis)
+		line := check + value + strings.Repeat(" ", valueWidth-ansi.StringWidth(value)+1) +
+			fmt.Sprintf("%*d", countWidth, facet.Count)
+		line = ansi.Truncate(line, innerWidth, "")
+		if i == r.facetPanel.cursor {
+			line = r.styles[StyleKeyFacetCursor].Width(innerWidth).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if footer != "" {
+		lines = append(lines, ansi.Truncate(footer, innerWidth, ""))
+	}
+
+	panel := r.styles[StyleKeyFacet].Width(innerWidth + 2).Render(strings.Join(lines, "\n"))
+	r.facetPanel.width, r.facetPanel.height = lipgloss.Width(panel), lipgloss.Height(panel)
+	return lipgloss.Place(r.width, r.rowsBox.GetHeight(), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// keyMatches reports whether the key is one of the keys
+func keyMatches(key string, keys []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+package table
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tableDefaultHistoryLimit number of operations kept in the history
+const tableDefaultHistoryLimit = 100
+
+// OperationKind is the kind of the change recorded in the history
+type OperationKind int
+
+const (
+	// OperationView is a change of the view, sorting, filtering, column order, visibility or width
+	OperationView OperationKind = iota
+	// OperationSetCell is a change of a single cell made with SetCell
+	OperationSetCell
+	// OperationUpdateRow is a change of the row values made with UpdateRow
+	OperationUpdateRow
+	// OperationRemoveRow is a removal of the row made with RemoveRow
+	OperationRemoveRow
+)
+
+// HistoryAction tells whether the operation is done for the first time, undone or redone
+type HistoryAction int
+
+const (
+	HistoryActionDo HistoryAction = iota
+	HistoryActionUndo
+	HistoryActionRedo
+)
+
+// Operation describes the change passed to the history hook
+type Operation struct {
+	Kind   OperationKind
+	Action HistoryAction
+	// Row is the changed row holding the values after the action, nil for view changes
+	Row []any
+	// Column is the index of the changed cell for OperationSetCell, -1 otherwise
+	Column int
+}
+
+// HistoryHook is called after the operation is applied, returning an error reverts the operation,
+// so the host app can persist the change and discard it if that fails
+type HistoryHook func(operation Operation) error
+
+// HistoryKeyMap keys undoing and redoing the operations, matched against tea.KeyMsg.String()
+type HistoryKeyMap struct {
+	Undo []string
+	Redo []string
+}
+
+// DefaultHistoryKeyMap returns the default history key map
+func DefaultHistoryKeyMap() HistoryKeyMap {
+	return HistoryKeyMap{
+		Undo: []string{"ctrl+z"},
+		Redo: []string{"ctrl+y"},
+	}
+}
+
+// HistoryErrorMsg is emitted when undoing or redoing with the keys is reverted by the history hook
+type HistoryErrorMsg struct {
+	Err error
+}
+
+// historyEntry is a recorded operation with functions reverting and reapplying it
+type historyEntry struct {
+	kind   OperationKind
+	row    []any
+	column int
+	// key merges consecutive view changes of the same kind into a single entry, e.g. typing a filter
+	key  string
+	undo func()
+	redo func()
+}
+
+// operation returns the operation passed to the hook for the action
+func (e historyEntry) operation(action HistoryAction) Operation {
+	return Operation{Kind: e.kind, Action: action, Row: e.row, Column: e.column}
+}
+
+// SetHistoryLimit sets the number of operations kept in the history, the oldest ones are dropped first,
+// 0 disables the history while the hook is still called
+func (r *Table) SetHistoryLimit(value int) *Table {
+	if value < 0 {
+		value = 0
+	}
+	r.historyLimit = value
+	if excess := len(r.history) - value; excess > 0 {
+		r.history = r.history[excess:]
+		r.historyIndex = clamp(r.historyIndex-excess, 0, len(r.history))
+	}
+	return r
+}
+
+// SetHistoryHook sets the hook called on every operation, done, undone or redone
+func (r *Table) SetHistoryHook(hook HistoryHook) *Table {
+	r.historyHook = hook
+	return r
+}
+
+// SetHistoryKeyMap replaces the keys undoing and redoing the operations
+func (r *Table) SetHistoryKeyMap(keyMap HistoryKeyMap) *Table {
+	r.historyKeyMap = keyMap
+	return r
+}
+
+// Undo reverts the last operation, does nothing if there is none
+func (r *Table) Undo() (*Table, error) {
+	if r.historyIndex == 0 {
+		return r, nil
+	}
+	entry := r.history[r.historyIndex-1]
+	entry.undo()
+	if err := r.callHistoryHook(entry, HistoryActionUndo); err != nil {
+		entry.redo()
+		return r, err
+	}
+	r.historyIndex--
+	return r, nil
+}
+
+// Redo reapplies the last undone operation, does nothing if there is none
+func (r *Table) Redo() (*Table, error) {
+	if r.historyIndex == len(r.history) {
+		return r, nil
+	}
+	entry := r.history[r.historyIndex]
+	entry.redo()
+	if err := r.callHistoryHook(entry, HistoryActionRedo); err != nil {
+		entry.undo()
+		return r, err
+	}
+	r.historyIndex++
+	return r, nil
+}
+
+// CanUndo reports whether there is an operation to undo
+func (r *Table) CanUndo() bool {
+	return r.historyIndex > 0
+}
+
+// CanRedo reports whether there is an operation to redo
+func (r *Table) CanRedo() bool {
+	return r.historyIndex < len(r.history)
+}
+
+// ClearHistory drops all the recorded operations, rows removing methods like ClearRows clear it as well
+func (r *Table) ClearHistory() *Table {
+	r.history = nil
+	r.historyIndex = 0
+	return r
+}
+
+// SetCell sets the value of the cell, row index is the position within the filtered rows as the cursor y
+// and column index is the index of the header, computed columns can not be set
+func (r *Table) SetCell(rowIndex, columnIndex int, value any) (*Table, error) {
+	if rowIndex < 0 || rowIndex >= len(r.filteredRows) {
+		message := fmt.Sprintf("row index %d out of range[%d]", rowIndex, len(r.filteredRows))
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	if err := r.checkColumnIndex(columnIndex); err != nil {
+		return r, err
+	}
+	if r.IsColumnComputed(columnIndex) {
+		message := fmt.Sprintf("column on index %d is computed and can not be set", columnIndex)
+		return r, ErrorBadValue{msg: message}
+	}
+	target := r.filteredRows[rowIndex]
+	values := append([]any(nil), target...)
+	values[columnIndex] = value
+	if err := r.validateRow(values...); err != nil {
+		return r, err
+	}
+	previous := target[columnIndex]
+	set := func(value any) func() {
+		return func() {
+			values := append([]any(nil), target...)
+			values[columnIndex] = value
+			r.setRowValues(target, values)
+		}
+	}
+	entry := historyEntry{kind: OperationSetCell, row: target, column: columnIndex, undo: set(previous), redo: set(value)}
+	entry.redo()
+	return r, r.record(entry)
+}
+
+// MustSetCell executes SetCell and panics if there is an error
+func (r *Table) MustSetCell(rowIndex, columnIndex int, value any) *Table {
+	if _, err := r.SetCell(rowIndex, columnIndex, value); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// RemoveRow removes the row with index n, index is the position within the filtered rows as the cursor y
+func (r *Table) RemoveRow(index int) (*Table, error) {
+	if index < 0 || index >= len(r.filteredRows) {
+		message := fmt.Sprintf("row index %d out of range[%d]", index, len(r.filteredRows))
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	target := r.filteredRows[index]
+	position := r.rowPosition(target)
+	entry := historyEntry{
+		kind:   OperationRemoveRow,
+		row:    target,
+		column: -1,
+		undo:   func() { r.insertRow(position, target) },
+		redo:   func() { r.removeRow(target) },
+	}
+	entry.redo()
+	return r, r.record(entry)
+}
+
+// MustRemoveRow executes RemoveRow and panics if there is an error
+func (r *Table) MustRemoveRow(index int) *Table {
+	if _, err := r.RemoveRow(index); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// record calls the hook for the applied operation and notes it in the history,
+// if the hook returns an error the operation is reverted
+func (r *Table) record(entry historyEntry) error {
+	if err := r.callHistoryHook(entry, HistoryActionDo); err != nil {
+		entry.undo()
+		return err
+	}
+	if r.historyLimit == 0 {
+		return nil
+	}
+	// new operation drops the undone ones
+	r.history = r.history[:r.historyIndex]
+	if last := len(r.history) - 1; entry.key != "" && last >= 0 && r.history[last].key == entry.key {
+		// merged entry undoes to the state before the first change
+		r.history[last].redo = entry.redo
+	} else {
+		r.history = append(r.history, entry)
+	}
+	if excess := len(r.history) - r.historyLimit; excess > 0 {
+		r.history = r.history[excess:]
+	}
+	r.historyIndex = len(r.history)
+	return nil
+}
+
+// recordView applies the view change with redo and records it as a single operation, undo and redo
+// set only the changed field so they do not depend on the size of the table. Callers skip recording
+// changes that leave the view as it was
+func (r *Table) recordView(key string, undo, redo func()) error {
+	redo()
+	return r.record(historyEntry{kind: OperationView, column: -1, key: key, undo: undo, redo: redo})
+}
+
+// callHistoryHook passes the operation to the hook if there is one
+func (r *Table) callHistoryHook(entry historyEntry, action HistoryAction) error {
+	if r.historyHook == nil {
+		return nil
+	}
+	return r.historyHook(entry.operation(action))
+}
+
+// handleHistoryKey undoes or redoes with the keys, returns a command emitting HistoryErrorMsg if it fails
+func (r *Table) handleHistoryKey(msg tea.KeyMsg) tea.Cmd {
+	var err error
+	switch key := msg.String(); {
+	case keyMatches(key, r.historyKeyMap.Undo):
+		_, err = r.Undo()
+	case keyMatches(key, r.historyKeyMap.Redo):
+		_, err = r.Redo()
+	}
+	if err != nil {
+		return func() tea.Msg { return HistoryErrorMsg{Err: err} }
+	}
+	return nil
+}
+
+// setRowValues copies the values into the row so it keeps its identity, computed values are evaluated again
+func (r *Table) setRowValues(row []any, values []any) {
+	copy(row, values)
+	r.invalidateComputed(row)
+	r.applyFilter()
+	r.setTopRow()
+	r.setRowsUpdate()
+}
+
+// rowPosition returns the index of the row within all the rows, -1 if it is not there
+func (r *Table) rowPosition(row []any) int {
+	for i, rw := range r.rows {
+		if rowAddress(rw) == rowAddress(row) {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeRow removes the row from the rows, the row keeps its id and selection so it can be inserted back
+func (r *Table) removeRow(row []any) {
+	position := r.rowPosition(row)
+	if position < 0 {
+		return
+	}
+	r.rows = append(r.rows[:position:position], r.rows[position+1:]...)
+	// computed values are evaluated again if the row is inserted back
+	r.invalidateComputed(row)
+	r.applyFilter()
+	r.setTopRow()
+	r.setRowsUpdate()
+}
+
+// insertRow inserts the row back on the position within the rows
+func (r *Table) insertRow(position int, row []any) {
+	position = clamp(position, 0, len(r.rows))
+	r.rows = append(r.rows[:position:position], append([][]any{row}, r.rows[position:]...)...)
+	r.applyFilter()
+	r.setTopRow()
+	r.setRowsUpdate()
+}
@@ -0,0 +1,198 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func newHistoryTable() *Table {
+	return NewTable(40, 10, []string{"name", "count"}).
+		MustSetTypes("", 0).
+		MustAddRows([][]any{{"b", 2}, {"a", 1}, {"c", 3}})
+}
+
+// names returns the names of the filtered rows in the displayed order
+func names(table *Table) string {
+	table.filterRows()
+	var names []any
+	for _, row := range table.filteredRows {
+		names = append(names, row[0])
+	}
+	return fmt.Sprint(names)
+}
+
+func TestUndoRedo(t *testing.T) {
+	tests := []struct {
+		name   string
+		do     func(*Table)
+		before string
+		after  string
+	}{
+		{"set cell", func(r *Table) { r.MustSetCell(0, 0, "x") }, "[b a c]", "[x a c]"},
+		{"update row", func(r *Table) { _, _ = r.UpdateRow(1, []any{"y", 9}) }, "[b a c]", "[b y c]"},
+		{"remove row", func(r *Table) { r.MustRemoveRow(1) }, "[b a c]", "[b c]"},
+		{"sort", func(r *Table) { r.MustOrderByAsc(0) }, "[b a c]", "[c b a]"},
+		{"filter", func(r *Table) { r.MustSetFilter(0, "a") }, "[b a c]", "[a]"},
+		{"value filter", func(r *Table) { r.MustSetValueFilter(0, "b", "c") }, "[b a c]", "[b c]"},
+		{"null filter", func(r *Table) { r.MustSetNullFilter(0, NullFilterEmpty) }, "[b a c]", "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newHistoryTable()
+			tt.do(table)
+			if got := names(table); got != tt.after {
+				t.Fatalf("done %s, want %s", got, tt.after)
+			}
+			if _, err := table.Undo(); err != nil || names(table) != tt.before {
+				t.Fatalf("undone %s (%v), want %s", names(table), err, tt.before)
+			}
+			if _, err := table.Redo(); err != nil || names(table) != tt.after {
+				t.Fatalf("redone %s (%v), want %s", names(table), err, tt.after)
+			}
+			if table.CanRedo() || !table.CanUndo() {
+				t.Errorf("can undo %v and redo %v, want only undo", table.CanUndo(), table.CanRedo())
+			}
+		})
+	}
+}
+
+func TestUndoColumnChanges(t *testing.T) {
+	table := newHistoryTable()
+	table.MustSetColumnWidth(1, 7)
+	if _, err := table.MoveColumn(1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.HideColumn(0); err != nil {
+		t.Fatal(err)
+	}
+	table.Undo()
+	if table.IsColumnHidden(0) {
+		t.Error("hiding not undone")
+	}
+	table.Undo()
+	if order := fmt.Sprint(table.GetColumnOrder()); order != "[0 1]" {
+		t.Errorf("column order %s, want the move undone", order)
+	}
+	table.Undo()
+	if width := table.GetColumnWidth(1); width != 0 {
+		t.Errorf("column width %d, want the lock undone", width)
+	}
+}
+
+func TestUndoSortKeepsRowsAddedLater(t *testing.T) {
+	table := newHistoryTable().MustOrderByAsc(0)
+	table.MustAddRows([][]any{{"d", 4}})
+	table.Undo()
+	if got := names(table); got != "[b a c d]" {
+		t.Errorf("undone %s, want the original order with the new row last", got)
+	}
+}
+
+func TestUnchangedViewIsNotRecorded(t *testing.T) {
+	table := newHistoryTable()
+	table.MustSetFilter(0, "")
+	table.UnsetValueFilters()
+	table.MustSetNullFilter(0, NullFilterNone)
+	if _, err := table.ShowColumn(0); err != nil {
+		t.Fatal(err)
+	}
+	if table.CanUndo() {
+		t.Error("change leaving the view as it was is recorded")
+	}
+}
+
+func TestFilterTypingMerges(t *testing.T) {
+	table := newHistoryTable()
+	for _, s := range []string{"c", "c ", "c"} {
+		table.MustSetFilter(1, s)
+	}
+	table.MustSetFilter(0, "a")
+	table.Undo()
+	if column, s := table.GetFilter(); column != 1 || s != "c" {
+		t.Fatalf("filter %d %q, want the typing on the other column kept", column, s)
+	}
+	table.Undo()
+	if column, s := table.GetFilter(); column != -1 || s != "" || table.CanUndo() {
+		t.Errorf("filter %d %q, want the whole typing undone at once", column, s)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	table := newHistoryTable().SetHistoryLimit(2)
+	for i := 0; i < 3; i++ {
+		table.MustSetCell(0, 1, 10+i)
+	}
+	table.Undo()
+	table.Undo()
+	if table.CanUndo() {
+		t.Error("more operations kept than the limit")
+	}
+	if value := table.filteredRows[0][1]; value != 10 {
+		t.Errorf("value %v, want the oldest kept operation undone to 10", value)
+	}
+
+	table.SetHistoryLimit(0).MustSetCell(0, 1, 5)
+	if table.CanUndo() || table.CanRedo() {
+		t.Error("operation recorded with the history disabled")
+	}
+}
+
+func TestNewOperationDropsRedo(t *testing.T) {
+	table := newHistoryTable()
+	table.MustSetCell(0, 1, 10)
+	table.Undo()
+	table.MustSetCell(0, 1, 20)
+	if table.CanRedo() {
+		t.Error("undone operation still redoable after a new one")
+	}
+}
+
+func TestHistoryHookRevertsOnError(t *testing.T) {
+	failing := errors.New("not persisted")
+	var calls []Operation
+	var fail bool
+	table := newHistoryTable().SetHistoryHook(func(operation Operation) error {
+		calls = append(calls, operation)
+		if fail {
+			return failing
+		}
+		return nil
+	})
+
+	fail = true
+	if _, err := table.SetCell(0, 1, 10); !errors.Is(err, failing) {
+		t.Fatalf("error %v, want the hook error", err)
+	}
+	if value := table.filteredRows[0][1]; value != 2 || table.CanUndo() {
+		t.Fatalf("value %v recorded %v, want the change reverted and not recorded", value, table.CanUndo())
+	}
+
+	fail = false
+	table.MustSetCell(0, 1, 10)
+	fail = true
+	if _, err := table.Undo(); !errors.Is(err, failing) {
+		t.Fatalf("undo error %v, want the hook error", err)
+	}
+	if value := table.filteredRows[0][1]; value != 10 || !table.CanUndo() {
+		t.Errorf("value %v, want the failed undo reverted and still undoable", value)
+	}
+	if _, err := table.OrderByAsc(0); !errors.Is(err, failing) || names(table) != "[b a c]" {
+		t.Errorf("sort error %v with rows %s, want the sort reverted", err, names(table))
+	}
+
+	want := []Operation{
+		{Kind: OperationSetCell, Action: HistoryActionDo, Column: 1},
+		{Kind: OperationSetCell, Action: HistoryActionDo, Column: 1},
+		{Kind: OperationSetCell, Action: HistoryActionUndo, Column: 1},
+		{Kind: OperationView, Action: HistoryActionDo, Column: -1},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("hook called %d times, want %d", len(calls), len(want))
+	}
+	for i, call := range calls {
+		if call.Kind != want[i].Kind || call.Action != want[i].Action || call.Column != want[i].Column {
+			t.Errorf("call %d = %+v, want %+v", i, call, want[i])
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package table
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tableDefaultWheelDelta number of rows scrolled by a single mouse wheel step
+const tableDefaultWheelDelta = 3
+
+// SetOrigin sets the screen position of the top left corner of the table, mouse events passed
+// to Update are in screen coordinates so they are translated using the origin
+func (r *Table) SetOrigin(x, y int) *Table {
+	r.originX, r.originY = x, y
+	return r
+}
+
+// SetColumnWidth locks the width of the column with index n, setting it to 0 reverts
+// the column to the ratio based width, dragging a header border with the mouse sets it too
+func (r *Table) SetColumnWidth(index, value int) (*Table, error) {
+	if index < 0 || index >= len(r.columnHeaders) {
+		message := fmt.Sprintf("column index %d out of range[%d]", index, len(r.columnHeaders))
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	if value < 0 {
+		message := fmt.Sprintf("column width value[%d] can not be negative", value)
+		return r, ErrorBadValue{msg: message}
+	}
+	if r.columnWidth[index] == value {
+		return r, nil
+	}
+	return r, r.recordView("", r.setColumnWidth(index, r.columnWidth[index]), r.setColumnWidth(index, value))
+}
+
+// MustSetColumnWidth executes SetColumnWidth and panics if there is an error
+func (r *Table) MustSetColumnWidth(index, value int) *Table {
+	if _, err := r.SetColumnWidth(index, value); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// setColumnWidth returns the function locking the width of the column, used to do and undo the change
+func (r *Table) setColumnWidth(index, value int) func() {
+	return func() {
+		r.columnWidth[index] = value
+		r.recalculateVisibleColumnRange()
+	}
+}
+
+// GetColumnWidth returns the locked width of the column with index n, 0 means the width is ratio based
+func (r *Table) GetColumnWidth(index int) int {
+	if index < 0 || index >= len(r.columnWidth) {
+		return 0
+	}
+	return r.columnWidth[index]
+}
+
+// ScrollDown moves the visible rows down by n, the cursor is kept within the visible rows
+func (r *Table) ScrollDown(n int) *Table {
+	maxTop := len(r.filteredRows) - r.rowsBoxHeight
+	if maxTop < 0 {
+		maxTop = 0
+	}
+	top := r.rowsTopIndex + n
+	if top > maxTop {
+		top = maxTop
+	}
+	r.scrollRowsTo(top)
+	return r
+}
+
+// ScrollUp moves the visible rows up by n, the cursor is kept within the visible rows
+func (r *Table) ScrollUp(n int) *Table {
+	top := r.rowsTopIndex - n
+	if top < 0 {
+		top = 0
+	}
+	r.scrollRowsTo(top)
+	return r
+}
+
+// scrollRowsTo sets the top visible row and pulls the cursor into the view,
+// otherwise the next setTopRow would jump back to the cursor
+func (r *Table) scrollRowsTo(top int) {
+	r.rowsTopIndex = top
+	if r.cursorIndexY < top {
+		r.cursorIndexY = top
+	}
+	if bottom := top + r.rowsBoxHeight - 1; r.cursorIndexY > bottom && bottom >= top {
+		r.cursorIndexY = bottom
+	}
+	r.setRowsUpdate()
+}
+
+// SortErrorMsg is emitted when sorting by clicking a header fails or is reverted by the history hook,
+// the sort stays as it was
+type SortErrorMsg struct {
+	Err error
+}
+
+// handleMouse handles clicking on cells and headers, wheel scrolling and header border dragging,
+// mouse has to be enabled on the bubbletea program e.g. with tea.WithMouseCellMotion.
+// Returns a command emitting SortErrorMsg if sorting by the header fails
+func (r *Table) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	x, y := msg.X-r.originX, msg.Y-r.originY
+
+	// header border is being dragged, every motion resizes the column
+	if r.resizeColumnIndex >= 0 {
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			width := r.resizeStartWidth + x - r.resizeStartX
+			if width < 1 {
+				width = 1
+			}
+			r.columnWidth[r.resizeColumnIndex] = width
+			r.resizeDragged = true
+			r.recalculateVisibleColumnRange()
+		case tea.MouseActionRelease:
+			index := r.resizeColumnIndex
+			r.resizeColumnIndex = -1
+			// border was clicked without dragging, it is a click on the header
+			if !r.resizeDragged {
+				return r.sortByHeader(index)
+			}
+			// the whole drag is a single operation, hook can only revert it
+			if width := r.columnWidth[index]; width != r.resizePreviousWidth {
+				_ = r.recordView("", r.setColumnWidth(index, r.resizePreviousWidth), r.setColumnWidth(index, width))
+			}
+		}
+		return nil
+	}
+
+	if tea.MouseEvent(msg).IsWheel() {
+		if msg.Action != tea.MouseActionPress {
+			return nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelDown:
+			if r.paginated {
+				_, _ = r.NextPage()
+				return nil
+			}
+			r.ScrollDown(tableDefaultWheelDelta)
+		case tea.MouseButtonWheelUp:
+			if r.paginated {
+				_, _ = r.PrevPage()
+				return nil
+			}
+			r.ScrollUp(tableDefaultWheelDelta)
+		case tea.MouseButtonWheelRight:
+			r.scrollColumnsRight()
+		case tea.MouseButtonWheelLeft:
+			r.scrollColumnsLeft()
+		}
+		return nil
+	}
+
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress {
+		return nil
+	}
+	columnPosition, columnOffset, columnWidth, ok := r.columnAt(x)
+	if !ok {
+		return nil
+	}
+	columnIndex := r.columnView[columnPosition]
+
+	// header, last cell of the column is a border that can be dragged, a click on it without
+	// dragging and anywhere else toggles sorting
+	if y == 0 {
+		if x == columnOffset+columnWidth-1 {
+			r.resizeColumnIndex = columnIndex
+			r.resizeDragged = false
+			r.resizeStartX = x
+			r.resizeStartWidth = columnWidth
+			r.resizePreviousWidth = r.columnWidth[columnIndex]
+			return nil
+		}
+		return r.sortByHeader(columnIndex)
+	}
+
+	// rows, clicking moves the cursor on the axis that the cursor mode tracks
+	rowIndex, ok := r.rowAt(y)
+	if !ok {
+		return nil
+	}
+	if r.cursorMode.tracksRow() {
+		r.cursorIndexY = rowIndex
+		r.setTopRow()
+	}
+	if r.cursorMode.tracksColumn() {
+		r.cursorIndexX = columnPosition
+		r.checkVisibleColumnRange()
+	}
+	r.setRowsUpdate()
+	return nil
+}
+
+// sortByHeader toggles sorting of the column with index n as a click on its header does, the first click
+// sorts descending. Sort can fail on cells that can not be compared or be reverted by the history hook,
+// the sort stays as it was and a command emitting SortErrorMsg is returned
+func (r *Table) sortByHeader(index int) tea.Cmd {
+	order := SortingOrderDescending
+	if r.orderedColumnIndex == index && r.orderedColumnPhase == SortingOrderDescending {
+		order = SortingOrderAscending
+	}
+	if err := r.recordSort(index, order); err != nil {
+		return func() tea.Msg { return SortErrorMsg{Err: err} }
+	}
+	return nil
+}
+
+// columnAt maps the x coordinate relative to the table to the display position of a visible column,
+// widths are taken from the rendered header so it matches what is on the screen
+func (r *Table) columnAt(x int) (position, offset, width int, ok bool) {
+	for i := 0; i <= r.columnVisibleRightIndex-r.columnVisibleLeftIndex; i++ {
+		cell := r.headerBox.GetRowCellCopy(0, i)
+		if cell == nil {
+			break
+		}
+		width = cell.GetWidth()
+		if x >= offset && x < offset+width {
+			return r.columnVisibleLeftIndex + i, offset, width, true
+		}
+		offset += width
+	}
+	return -1, 0, 0, false
+}
+
+// rowAt maps the y coordinate relative to the table to the index of a visible row
+func (r *Table) rowAt(y int) (int, bool) {
+	// header takes the first line
+	y--
+	if y < 0 || y >= r.rowsBoxHeight || r.rowHeight < 1 {
+		return -1, false
+	}
+	index := r.rowsTopIndex + y/r.rowHeight
+	if index >= len(r.filteredRows) {
+		return -1, false
+	}
+	return index, true
+}
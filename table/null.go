@@ -0,0 +1,136 @@
+package table
+
+import "fmt"
+
+// tableDefaultNullDisplay is rendered in place of the cells holding no value
+const tableDefaultNullDisplay = "—"
+
+// NullsOrder decides where the cells holding no value are placed when sorting
+type NullsOrder int
+
+const (
+	// NullsLast places the null cells after the values regardless of the sort direction, this is the default
+	NullsLast NullsOrder = iota
+	// NullsFirst places the null cells before the values regardless of the sort direction
+	NullsFirst
+)
+
+// NullFilter filters the column by presence of the value
+type NullFilter int
+
+const (
+	// NullFilterNone does not filter the column
+	NullFilterNone NullFilter = iota
+	// NullFilterEmpty keeps the rows where the cell is null or an empty string
+	NullFilterEmpty
+	// NullFilterNotEmpty keeps the rows where the cell holds a non-empty value
+	NullFilterNotEmpty
+)
+
+// MarshalText implements encoding.TextMarshaler so the filter is readable in the serialized State
+func (f NullFilter) MarshalText() ([]byte, error) {
+	switch f {
+	case NullFilterNone:
+		return []byte(""), nil
+	case NullFilterEmpty:
+		return []byte("empty"), nil
+	case NullFilterNotEmpty:
+		return []byte("not_empty"), nil
+	default:
+		return nil, ErrorBadValue{msg: fmt.Sprintf("unknown null filter %d", int(f))}
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (f *NullFilter) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "":
+		*f = NullFilterNone
+	case "empty":
+		*f = NullFilterEmpty
+	case "not_empty":
+		*f = NullFilterNotEmpty
+	default:
+		return ErrorBadValue{msg: fmt.Sprintf("unknown null filter %q", string(text))}
+	}
+	return nil
+}
+
+// SetNullDisplay sets the string rendered in place of the cells holding no value, defaults to "—"
+func (r *Table) SetNullDisplay(value string) *Table {
+	r.nullDisplay = value
+	r.setRowsUpdate()
+	return r
+}
+
+// SetNullsOrder sets where the cells holding no value are placed when sorting, it applies to the next sort
+func (r *Table) SetNullsOrder(order NullsOrder) *Table {
+	r.nullsOrder = order
+	return r
+}
+
+// SetNullFilter filters the column with index n to the rows with empty or non-empty cells,
+// NullFilterNone removes the filter, it is combined with the other filters
+func (r *Table) SetNullFilter(index int, filter NullFilter) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	previous := r.GetNullFilter(index)
+	if previous == filter {
+		return r, nil
+	}
+	return r, r.recordView("", r.setNullFilter(index, previous), r.setNullFilter(index, filter))
+}
+
+// setNullFilter returns the function setting the null filter of the column, used to do and undo the change
+func (r *Table) setNullFilter(index int, filter NullFilter) func() {
+	return func() {
+		if filter == NullFilterNone {
+			delete(r.nullFilters, index)
+		} else {
+			if r.nullFilters == nil {
+				r.nullFilters = make(map[int]NullFilter)
+			}
+			r.nullFilters[index] = filter
+		}
+		r.applyFilter()
+		r.setTopRow()
+		r.setRowsUpdate()
+		r.setHeadersUpdate()
+	}
+}
+
+// MustSetNullFilter executes SetNullFilter and panics if there is an error
+func (r *Table) MustSetNullFilter(index int, filter NullFilter) *Table {
+	if _, err := r.SetNullFilter(index, filter); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// GetNullFilter returns the null filter of the column with index n
+func (r *Table) GetNullFilter(index int) NullFilter {
+	return r.nullFilters[index]
+}
+
+// matchesNullFilter reports whether the value passes the null filter
+func matchesNullFilter(value any, filter NullFilter) bool {
+	empty := value == nil || value == ""
+	switch filter {
+	case NullFilterEmpty:
+		return empty
+	case NullFilterNotEmpty:
+		return !empty
+	default:
+		return true
+	}
+}
+
+// displayValue returns the text of the cell as it is rendered, null cells are rendered with the null display
+func (r *Table) displayValue(row []any, index int) string {
+	value := r.cellValue(row, index)
+	if value == nil {
+		return r.nullDisplay
+	}
+	return getStringFromOrdered(value)
+}
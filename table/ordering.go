@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 type Ordered interface {
@@ -17,35 +19,146 @@ const (
 	SortingOrderDescending
 )
 
+// MarshalText implements encoding.TextMarshaler so the order is readable in the serialized State
+func (k SortingOrderKey) MarshalText() ([]byte, error) {
+	switch k {
+	case SortingOrderAscending:
+		return []byte("asc"), nil
+	case SortingOrderDescending:
+		return []byte("desc"), nil
+	default:
+		return nil, ErrorBadValue{msg: fmt.Sprintf("unknown sorting order %d", int(k))}
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (k *SortingOrderKey) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "asc":
+		*k = SortingOrderAscending
+	case "desc":
+		*k = SortingOrderDescending
+	default:
+		return ErrorBadValue{msg: fmt.Sprintf("unknown sorting order %q", string(text))}
+	}
+	return nil
+}
+
+// sortEntry is a sort applied to the table, entries are kept in the order they were applied
+// and since sorting is stable replaying them reproduces the same row order
+type sortEntry struct {
+	column int
+	order  SortingOrderKey
+}
+
 // GetOrder returns the current order column index and phase
 func (r *Table) GetOrder() (int, SortingOrderKey) { return r.orderedColumnIndex, r.orderedColumnPhase }
 
-// OrderByAsc orders rows by a column with index n, in ascending order
-func (r *Table) OrderByAsc(index int) *Table {
-	// sanity check first, we won't return errors here, simply ignore if the user sends non-existing index
-	if index < len(r.columnHeaders) && len(r.filteredRows) > 1 {
-		r.orderedColumnPhase = SortingOrderAscending
-		r.rows = sortRows(r.rows, index, r.orderedColumnPhase)
-		r.setRowsUpdate()
-		r.orderedColumnIndex = index
-		r.setHeadersUpdate()
+// OrderByAsc orders rows by a column with index n, in ascending order,
+// returns an error if the column does not exist or its values can not be sorted
+func (r *Table) OrderByAsc(index int) (*Table, error) {
+	return r.recordOrderBy(index, SortingOrderAscending)
+}
+
+// MustOrderByAsc executes OrderByAsc and panics if there is an error
+func (r *Table) MustOrderByAsc(index int) *Table {
+	if _, err := r.OrderByAsc(index); err != nil {
+		panic(err)
 	}
 	return r
 }
 
-// OrderByDesc orders rows by a column with index n, in descending order
-func (r *Table) OrderByDesc(index int) *Table {
-	// sanity check first, we won't return errors here, simply ignore if the user sends non existing index
-	if index < len(r.columnHeaders) && len(r.filteredRows) > 1 {
-		r.orderedColumnPhase = SortingOrderDescending
-		r.rows = sortRows(r.rows, index, r.orderedColumnPhase)
-		r.setRowsUpdate()
-		r.orderedColumnIndex = index
-		r.setHeadersUpdate()
+// OrderByDesc orders rows by a column with index n, in descending order,
+// returns an error if the column does not exist or its values can not be sorted
+func (r *Table) OrderByDesc(index int) (*Table, error) {
+	return r.recordOrderBy(index, SortingOrderDescending)
+}
+
+// recordOrderBy sorts the rows and records the sort in the history
+func (r *Table) recordOrderBy(index int, order SortingOrderKey) (*Table, error) {
+	return r, r.recordSort(index, order)
+}
+
+// recordSort sorts the rows and records the sort, undo puts the rows back in the order they were in
+// before the sort instead of replaying the sort stack, redo sorts them again by the column
+func (r *Table) recordSort(index int, order SortingOrderKey) error {
+	rows := append([][]any(nil), r.rows...)
+	stack := append([]sortEntry(nil), r.sortStack...)
+	column, phase := r.orderedColumnIndex, r.orderedColumnPhase
+	if _, err := r.orderBy(index, order); err != nil {
+		return err
+	}
+	if column == index && phase == order && equalSortStacks(stack, r.sortStack) {
+		return nil
+	}
+	return r.record(historyEntry{
+		kind:   OperationView,
+		column: -1,
+		undo: func() {
+			r.rows = r.rowsInOrder(rows)
+			r.sortStack = append([]sortEntry(nil), stack...)
+			r.orderedColumnIndex, r.orderedColumnPhase = column, phase
+			r.setRowsUpdate()
+			r.setHeadersUpdate()
+		},
+		redo: func() { _, _ = r.orderBy(index, order) },
+	})
+}
+
+// MustOrderByDesc executes OrderByDesc and panics if there is an error
+func (r *Table) MustOrderByDesc(index int) *Table {
+	if _, err := r.OrderByDesc(index); err != nil {
+		panic(err)
 	}
 	return r
 }
 
+// orderBy sorts the rows by a column with index n in the given order
+func (r *Table) orderBy(index int, order SortingOrderKey) (*Table, error) {
+	if index < 0 || index >= len(r.columnHeaders) {
+		message := fmt.Sprintf("order column index %d out of range[%d]", index, len(r.columnHeaders))
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	// nothing to sort, the order is still noted so it is part of the State
+	if len(r.rows) > 1 {
+		sorted, err := r.sortRows(r.rows, index, order)
+		if err != nil {
+			return r, err
+		}
+		r.rows = sorted
+	}
+	r.orderedColumnPhase = order
+	r.orderedColumnIndex = index
+	r.pushSortEntry(index, order)
+	r.setRowsUpdate()
+	r.setHeadersUpdate()
+	return r, nil
+}
+
+// pushSortEntry notes the sort on top of the sort stack, older sort of the same column is dropped
+func (r *Table) pushSortEntry(index int, order SortingOrderKey) {
+	var stack []sortEntry
+	for _, entry := range r.sortStack {
+		if entry.column != index {
+			stack = append(stack, entry)
+		}
+	}
+	r.sortStack = append(stack, sortEntry{column: index, order: order})
+}
+
+// equalSortStacks reports whether both sort stacks hold the same sorts in the same order
+func equalSortStacks(a, b []sortEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // updateOrderedVars updates bits and pieces revolving around ordering
 // toggling between asc and desc
 // updating ordering vars on TableOrdered
@@ -67,21 +180,40 @@ func (r *Table) updateOrderedVars(index int) {
 	r.setHeadersUpdate()
 }
 
-func sortRows(rows [][]any, index int, orderKey SortingOrderKey) [][]any {
+// sortRows sorts the rows by a column with index n, null cells are kept out of sorting
+// and placed before or after the values depending on the nulls order
+func (r *Table) sortRows(rows [][]any, index int, orderKey SortingOrderKey) ([][]any, error) {
 	// sorted rows
 	var sorted [][]any
+	// rows holding a value and rows holding null, both keep their relative order
+	var valued, nulls [][]any
 	// list of column values used for ordering
 	var orderingCol []any
 	for _, rw := range rows {
-		orderingCol = append(orderingCol, rw[index])
+		value := r.cellValue(rw, index)
+		if value == nil {
+			nulls = append(nulls, rw)
+			continue
+		}
+		valued = append(valued, rw)
+		orderingCol = append(orderingCol, value)
 	}
 	// get sorting index
-	sortingIndex := sortIndexByOrderedColumn(orderingCol, orderKey)
+	sortingIndex, err := sortIndexByOrderedColumn(orderingCol, orderKey)
+	if err != nil {
+		return rows, err
+	}
+	if r.nullsOrder == NullsFirst {
+		sorted = append(sorted, nulls...)
+	}
 	// update rows
 	for _, i := range sortingIndex {
-		sorted = append(sorted, rows[i])
+		sorted = append(sorted, valued[i])
 	}
-	return sorted
+	if r.nullsOrder == NullsLast {
+		sorted = append(sorted, nulls...)
+	}
+	return sorted, nil
 }
 
 // isOrdered check if type is one of valid Ordered types
@@ -122,65 +254,59 @@ func getStringFromOrdered(i any) string {
 
 // sortIndexByOrderedColumn casts to the one of Ordered type that is used on the column and sends to sorting
 // returns sorted index of elements rather than elements themselves
-func sortIndexByOrderedColumn(i []any, order SortingOrderKey) (sortedIndex []int) {
+func sortIndexByOrderedColumn(i []any, order SortingOrderKey) (sortedIndex []int, err error) {
 	// if len of slice is 0 return empty sort order
 	if len(i) == 0 {
-		return sortedIndex
+		return sortedIndex, nil
 	}
 
 	switch i[0].(type) {
 	case string:
-		var s []string
+		// styled strings are ordered by their visible text
+		var stripped []any
 		for _, el := range i {
-			s = append(s, el.(string))
+			if s, ok := el.(string); ok {
+				el = ansi.Strip(s)
+			}
+			stripped = append(stripped, el)
 		}
-		return sortIndex(s, order)
+		return sortIndexAs[string](stripped, order)
 	case int:
-		var s []int
-		for _, el := range i {
-			s = append(s, el.(int))
-		}
-		return sortIndex(s, order)
+		return sortIndexAs[int](i, order)
 	case int8:
-		var s []int8
-		for _, el := range i {
-			s = append(s, el.(int8))
-		}
-		return sortIndex(s, order)
+		return sortIndexAs[int8](i, order)
 	case int16:
-		var s []int16
-		for _, el := range i {
-			s = append(s, el.(int16))
-		}
-		return sortIndex(s, order)
+		return sortIndexAs[int16](i, order)
 	case int32:
-		var s []int32
-		for _, el := range i {
-			s = append(s, el.(int32))
-		}
-		return sortIndex(s, order)
+		return sortIndexAs[int32](i, order)
 	case int64:
-		var s []int64
-		for _, el := range i {
-			s = append(s, el.(int64))
-		}
-		return sortIndex(s, order)
+		return sortIndexAs[int64](i, order)
 	case float32:
-		var s []float32
-		for _, el := range i {
-			s = append(s, el.(float32))
-		}
-		return sortIndex(s, order)
+		return sortIndexAs[float32](i, order)
 	case float64:
-		var s []float64
-		for _, el := range i {
-			s = append(s, el.(float64))
-		}
-		return sortIndex(s, order)
-
+		return sortIndexAs[float64](i, order)
 	default:
-		panic(fmt.Sprintf("type %s not subtype of Ordered", reflect.TypeOf(i[0]).String()))
+		message := fmt.Sprintf("type %s not subtype of Ordered", reflect.TypeOf(i[0]).String())
+		return nil, ErrorBadType{msg: message}
+	}
+}
+
+// sortIndexAs casts all the elements to T and sorts them, returns an error on the first
+// element that is not of type T
+func sortIndexAs[T Ordered](i []any, order SortingOrderKey) ([]int, error) {
+	var s []T
+	for index, el := range i {
+		v, ok := el.(T)
+		if !ok {
+			message := fmt.Sprintf(
+				"type of the cell[%v] on row %d not matching the type of the column[%v]",
+				reflect.TypeOf(el), index, reflect.TypeOf(i[0]),
+			)
+			return nil, ErrorBadCellType{msg: message}
+		}
+		s = append(s, v)
 	}
+	return sortIndex(s, order), nil
 }
 
 // sortIndex is simple generic bubble sort, returns sorted index slice
@@ -0,0 +1,266 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	tableDefaultPaginatorActiveDot   = "•"
+	tableDefaultPaginatorInactiveDot = "○"
+)
+
+// DataSource provides the rows of a paginated table page by page, only the rows of the current
+// page are requested so the source can be backed by a remote service or a database.
+// Sorting and filtering of the table apply to the rows of the current page only, the source
+// has to return the rows already sorted and filtered when they should apply to all the rows
+type DataSource interface {
+	// Len returns the total number of rows
+	Len() int
+	// Rows returns up to limit rows starting with the row on the offset
+	Rows(offset, limit int) ([][]any, error)
+}
+
+// SliceDataSource is a DataSource holding the rows in memory, it can stand in for a remote source in tests
+type SliceDataSource struct {
+	rows [][]any
+}
+
+// NewSliceDataSource initialize SliceDataSource with the rows
+func NewSliceDataSource(rows [][]any) *SliceDataSource {
+	return &SliceDataSource{rows: rows}
+}
+
+// Len returns the number of rows
+func (s *SliceDataSource) Len() int {
+	return len(s.rows)
+}
+
+// Rows returns up to limit rows starting with the row on the offset
+func (s *SliceDataSource) Rows(offset, limit int) ([][]any, error) {
+	if offset < 0 || limit < 0 {
+		message := fmt.Sprintf("rows offset %d and limit %d can not be negative", offset, limit)
+		return nil, ErrorIndexOutOfRange{msg: message}
+	}
+	if offset >= len(s.rows) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(s.rows) {
+		end = len(s.rows)
+	}
+	return s.rows[offset:end], nil
+}
+
+// PaginatorType is the style of the paginator rendered in the footer
+type PaginatorType int
+
+const (
+	// PaginatorDots renders a dot per page, it falls back to numbers when dots do not fit, this is the default
+	PaginatorDots PaginatorType = iota
+	// PaginatorNumbers renders the current page and the number of pages
+	PaginatorNumbers
+)
+
+// PageKeyMap keys switching pages of a paginated table, matched against tea.KeyMsg.String()
+type PageKeyMap struct {
+	Next []string
+	Prev []string
+}
+
+// DefaultPageKeyMap returns the default page key map
+func DefaultPageKeyMap() PageKeyMap {
+	return PageKeyMap{
+		Next: []string{"pgdown"},
+		Prev: []string{"pgup"},
+	}
+}
+
+// PageErrorMsg is emitted when switching pages with the keys fails to load the rows from the DataSource
+type PageErrorMsg struct {
+	Err error
+}
+
+// SetPagination switches between continuous scrolling and pages that fill the rows box,
+// while paginated Update switches pages with the keys of PageKeyMap
+func (r *Table) SetPagination(value bool) *Table {
+	// data source is always paginated
+	r.paginated = value || r.dataSource != nil
+	r.setTopRow()
+	r.setRowsUpdate()
+	return r
+}
+
+// IsPaginated reports whether the table is in paginated mode
+func (r *Table) IsPaginated() bool {
+	return r.paginated
+}
+
+// SetPaginatorType sets the style of the paginator rendered in the footer
+func (r *Table) SetPaginatorType(paginatorType PaginatorType) *Table {
+	r.paginatorType = paginatorType
+	return r
+}
+
+// SetPageKeyMap replaces the keys switching pages
+func (r *Table) SetPageKeyMap(keyMap PageKeyMap) *Table {
+	r.pageKeyMap = keyMap
+	return r
+}
+
+// SetDataSource paginates the table over the source and loads the first page, rows of the page
+// replace the rows of the table, so sorting and filtering apply to the current page only.
+// Setting nil detaches the source and keeps the rows of the current page
+func (r *Table) SetDataSource(source DataSource) (*Table, error) {
+	r.dataSource = source
+	if source == nil {
+		return r, nil
+	}
+	r.paginated = true
+	r.cursorIndexY = 0
+	return r, r.loadPage(0)
+}
+
+// GetPage returns the index of the current page and the number of pages, there is always at least one page
+func (r *Table) GetPage() (int, int) {
+	size := r.pageSize()
+	total := len(r.filteredRows)
+	page := r.rowsTopIndex / size
+	if r.dataSource != nil {
+		total = r.dataSource.Len()
+		page = r.page
+	}
+	pages := (total + size - 1) / size
+	if pages < 1 {
+		pages = 1
+	}
+	return page, pages
+}
+
+// SetPage switches to the page with index n, the cursor keeps its position within the page
+func (r *Table) SetPage(page int) (*Table, error) {
+	_, pages := r.GetPage()
+	if page < 0 || page >= pages {
+		message := fmt.Sprintf("page index %d out of range[%d]", page, pages)
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	if r.dataSource != nil {
+		return r, r.loadPage(page)
+	}
+	size := r.pageSize()
+	r.cursorIndexY = clamp(page*size+r.cursorIndexY%size, 0, len(r.filteredRows)-1)
+	r.setTopRow()
+	r.setRowsUpdate()
+	return r, nil
+}
+
+// NextPage switches to the next page, does nothing on the last page
+func (r *Table) NextPage() (*Table, error) {
+	page, pages := r.GetPage()
+	if page+1 >= pages {
+		return r, nil
+	}
+	return r.SetPage(page + 1)
+}
+
+// PrevPage switches to the previous page, does nothing on the first page
+func (r *Table) PrevPage() (*Table, error) {
+	page, _ := r.GetPage()
+	if page == 0 {
+		return r, nil
+	}
+	return r.SetPage(page - 1)
+}
+
+// pageSize number of rows on a page, rows box is filled with a single page
+func (r *Table) pageSize() int {
+	if r.rowsBoxHeight < 1 {
+		return 1
+	}
+	return r.rowsBoxHeight
+}
+
+// loadPage requests the rows of the page from the data source and replaces the rows with them,
+// sort stack is replayed on the page so the sort indicator stays true
+func (r *Table) loadPage(page int) error {
+	size := r.pageSize()
+	rows, err := r.dataSource.Rows(page*size, size)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := r.validateRow(row...); err != nil {
+			return err
+		}
+	}
+	for _, row := range r.rows {
+		r.forgetRow(row)
+	}
+	r.rows = r.ownRows(rows)
+	r.page = page
+	r.resetComputed()
+	// operations refer to the rows of the previous page
+	r.ClearHistory()
+	for _, entry := range r.sortStack {
+		if sorted, err := r.sortRows(r.rows, entry.column, entry.order); err == nil {
+			r.rows = sorted
+		}
+	}
+	r.applyFilter()
+	r.setTopRow()
+	r.setRowsUpdate()
+	return nil
+}
+
+// crossPage loads the neighbouring page with the cursor on the row, the cursor stays where it is
+// when the page fails to load
+func (r *Table) crossPage(page, cursor int) {
+	previous := r.cursorIndexY
+	r.cursorIndexY = cursor
+	if err := r.loadPage(page); err != nil {
+		r.cursorIndexY = previous
+	}
+}
+
+// reloadPage loads the page holding the first row of the current page again, used when the page size changes
+func (r *Table) reloadPage(previousSize int) {
+	if r.dataSource == nil {
+		return
+	}
+	_ = r.loadPage(r.page * previousSize / r.pageSize())
+}
+
+// handlePageKey switches pages with the keys, returns a command emitting PageErrorMsg if it fails
+func (r *Table) handlePageKey(msg tea.KeyMsg) tea.Cmd {
+	var err error
+	switch key := msg.String(); {
+	case keyMatches(key, r.pageKeyMap.Next):
+		_, err = r.NextPage()
+	case keyMatches(key, r.pageKeyMap.Prev):
+		_, err = r.PrevPage()
+	}
+	if err != nil {
+		return func() tea.Msg { return PageErrorMsg{Err: err} }
+	}
+	return nil
+}
+
+// renderPaginator renders the paginator shown in the footer of a paginated table
+func (r *Table) renderPaginator() string {
+	page, pages := r.GetPage()
+	// dots should take no more than half of the footer
+	if r.paginatorType == PaginatorDots && pages*2 <= r.width {
+		var s strings.Builder
+		for i := 0; i < pages; i++ {
+			if i == page {
+				s.WriteString(tableDefaultPaginatorActiveDot)
+			} else {
+				s.WriteString(tableDefaultPaginatorInactiveDot)
+			}
+		}
+		return s.String()
+	}
+	return fmt.Sprintf("%d/%d", page+1, pages)
+}
@@ -0,0 +1,133 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// recordingSource records the rows requested from the SliceDataSource
+type recordingSource struct {
+	*SliceDataSource
+	requests [][2]int
+}
+
+func (s *recordingSource) Rows(offset, limit int) ([][]any, error) {
+	s.requests = append(s.requests, [2]int{offset, limit})
+	return s.SliceDataSource.Rows(offset, limit)
+}
+
+// newPagedTable returns table with page size of 3 over 10 rows
+func newPagedTable(t *testing.T) (*Table, *recordingSource) {
+	t.Helper()
+	var rows [][]any
+	for i := 0; i < 10; i++ {
+		rows = append(rows, []any{fmt.Sprintf("row %d", i)})
+	}
+	source := &recordingSource{SliceDataSource: NewSliceDataSource(rows)}
+	table, err := NewTable(40, 5, []string{"name"}).SetDataSource(source)
+	if err != nil {
+		t.Fatalf("set data source: %v", err)
+	}
+	return table, source
+}
+
+func TestDataSourceRequestsCurrentPage(t *testing.T) {
+	table, source := newPagedTable(t)
+	if _, err := table.SetPage(2); err != nil {
+		t.Fatalf("set page: %v", err)
+	}
+	want := [][2]int{{0, 3}, {6, 3}}
+	if fmt.Sprint(source.requests) != fmt.Sprint(want) {
+		t.Errorf("requests %v, want %v", source.requests, want)
+	}
+	if len(table.rows) != 3 || table.rows[0][0] != "row 6" {
+		t.Errorf("loaded rows %v, want rows 6-8", table.rows)
+	}
+}
+
+func TestPageSwitching(t *testing.T) {
+	tests := []struct {
+		name    string
+		move    func(*Table) (*Table, error)
+		start   int
+		want    int
+		wantErr bool
+	}{
+		{"next", (*Table).NextPage, 0, 1, false},
+		{"next on last page", (*Table).NextPage, 3, 3, false},
+		{"prev", (*Table).PrevPage, 2, 1, false},
+		{"prev on first page", (*Table).PrevPage, 0, 0, false},
+		{"set last", func(r *Table) (*Table, error) { return r.SetPage(3) }, 0, 3, false},
+		{"set past last", func(r *Table) (*Table, error) { return r.SetPage(4) }, 1, 1, true},
+		{"set negative", func(r *Table) (*Table, error) { return r.SetPage(-1) }, 1, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := newPagedTable(t)
+			setPage(t, table, tt.start)
+			_, err := tt.move(table)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error %v, want error %v", err, tt.wantErr)
+			}
+			if page, pages := table.GetPage(); page != tt.want || pages != 4 {
+				t.Errorf("page %d/%d, want %d/4", page, pages, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorCrossesPages(t *testing.T) {
+	table, _ := newPagedTable(t)
+	for i := 0; i < 3; i++ {
+		table.CursorDown()
+	}
+	if page, _ := table.GetPage(); page != 1 || table.GetCursorValue() != "row 3" {
+		t.Fatalf("page %d cursor on %q, want page 1 on row 3", page, table.GetCursorValue())
+	}
+	table.CursorUp()
+	if page, _ := table.GetPage(); page != 0 || table.GetCursorValue() != "row 2" {
+		t.Fatalf("page %d cursor on %q, want page 0 on row 2", page, table.GetCursorValue())
+	}
+	setPage(t, table, 3)
+	table.CursorDown()
+	if page, _ := table.GetPage(); page != 3 || table.GetCursorValue() != "row 9" {
+		t.Errorf("page %d cursor on %q, want to stay on row 9 of the last page", page, table.GetCursorValue())
+	}
+}
+
+func TestPaginatorFooter(t *testing.T) {
+	tests := []struct {
+		name          string
+		paginatorType PaginatorType
+		width         int
+		page          int
+		want          string
+	}{
+		{"dots", PaginatorDots, 40, 1, "○•○○"},
+		{"dots fall back to numbers", PaginatorDots, 7, 1, "2/4"},
+		{"numbers", PaginatorNumbers, 40, 3, "4/4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := newPagedTable(t)
+			table.SetWidth(tt.width)
+			setPage(t, table.SetPaginatorType(tt.paginatorType), tt.page)
+			if got := table.renderPaginator(); got != tt.want {
+				t.Errorf("paginator %q, want %q", got, tt.want)
+			}
+			if rendered := ansi.Strip(table.Render()); !strings.Contains(rendered, tt.want) {
+				t.Errorf("rendered table does not show %q:\n%s", tt.want, rendered)
+			}
+		})
+	}
+}
+
+func setPage(t *testing.T, table *Table, page int) {
+	t.Helper()
+	if _, err := table.SetPage(page); err != nil {
+		t.Fatalf("set page %d: %v", page, err)
+	}
+}
@@ -0,0 +1,272 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	// tableSparklineChars bars of the sparkline from the lowest to the highest value
+	tableSparklineChars = []rune("▁▂▃▄▅▆▇█")
+	// tableProgressChars partially filled block of the progress bar, in eighths
+	tableProgressChars = []rune(" ▏▎▍▌▋▊▉█")
+)
+
+const (
+	tableProgressEmptyChar = "░"
+	tableCheckTrue         = "✓"
+	tableCheckFalse        = "✗"
+)
+
+// CellRenderer draws the value of the cell within the width and height available to the cell,
+// values of the column do not have to be Ordered, they are sorted, filtered and summarized by their scalar
+type CellRenderer interface {
+	// Render draws the value, it is called with the size of the cell every time the cell is rendered
+	Render(value any, width, height int) string
+	// Scalar returns the Ordered value standing for the value, it has to be of the column type set with
+	// SetTypes, reports false if the renderer does not accept the value
+	Scalar(value any) (any, bool)
+}
+
+// SetColumnRenderer draws the cells of the column with index n with the renderer, nil resets the column
+// to plain text. Cells of the column are validated by the renderer instead of the column type, the rows
+// already in the table are validated again and an error is returned if any of them does not fit
+func (r *Table) SetColumnRenderer(index int, renderer CellRenderer) (*Table, error) {
+	if err := r.checkColumnIndex(index); err != nil {
+		return r, err
+	}
+	if index < len(r.columnType) {
+		for _, row := range r.rows {
+			if err := r.validateCell(renderer, row[index], index); err != nil {
+				return r, err
+			}
+		}
+	}
+	if renderer == nil {
+		delete(r.columnRenderers, index)
+	} else {
+		if r.columnRenderers == nil {
+			r.columnRenderers = make(map[int]CellRenderer)
+		}
+		r.columnRenderers[index] = renderer
+	}
+	// facets are counted on the scalars of the renderer and computed values are validated by it
+	r.resetFacets()
+	if r.IsColumnComputed(index) {
+		r.resetComputed()
+	}
+	r.setRowsUpdate()
+	return r, nil
+}
+
+// MustSetColumnRenderer executes SetColumnRenderer and panics if there is an error
+func (r *Table) MustSetColumnRenderer(index int, renderer CellRenderer) *Table {
+	if _, err := r.SetColumnRenderer(index, renderer); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// validateRenderedCell checks the cell of the column drawn by a renderer, its scalar has to be of the column type
+func (r *Table) validateRenderedCell(renderer CellRenderer, cell any, index int) error {
+	scalar, ok := renderer.Scalar(cell)
+	if !ok {
+		message := fmt.Sprintf(
+			"type of the cell[%v] on index %d not accepted by the column renderer", reflect.TypeOf(cell), index,
+		)
+		return ErrorBadCellType{msg: message}
+	}
+	if columnType := r.columnTypeAt(index); scalar != nil && reflect.TypeOf(scalar) != reflect.TypeOf(columnType) {
+		message := fmt.Sprintf(
+			"type of the scalar[%v] of the cell on index %d not matching type of the column[%v]",
+			reflect.TypeOf(scalar), index, reflect.TypeOf(columnType),
+		)
+		return ErrorBadCellType{msg: message}
+	}
+	return nil
+}
+
+// sparklineRenderer draws []float64 as a sparkline
+type sparklineRenderer struct{}
+
+// NewSparklineRenderer returns a renderer drawing []float64 cells as a sparkline of the latest values
+// that fit the cell, scaled between the lowest and the highest of them, cells are sorted by the latest value
+// so the column type has to be float64
+func NewSparklineRenderer() CellRenderer {
+	return sparklineRenderer{}
+}
+
+// Render draws the latest values that fit the width
+func (sparklineRenderer) Render(value any, width, _ int) string {
+	values, _ := value.([]float64)
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	// values that are not finite are left empty and do not count towards the scale
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if isFinite(v) {
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+	}
+	var s strings.Builder
+	for _, v := range values {
+		if !isFinite(v) {
+			s.WriteRune(' ')
+			continue
+		}
+		level := len(tableSparklineChars) / 2
+		if high > low {
+			level = int((v - low) / (high - low) * float64(len(tableSparklineChars)-1))
+		}
+		level = max(0, min(len(tableSparklineChars)-1, level))
+		s.WriteRune(tableSparklineChars[level])
+	}
+	return s.String()
+}
+
+// Scalar returns the latest value
+func (sparklineRenderer) Scalar(value any) (any, bool) {
+	values, ok := value.([]float64)
+	if !ok {
+		return nil, false
+	}
+	if len(values) == 0 {
+		return 0.0, true
+	}
+	return values[len(values)-1], true
+}
+
+// progressRenderer draws float64 between 0 and 1 as a progress bar
+type progressRenderer struct{}
+
+// NewProgressRenderer returns a renderer drawing float64 cells between 0 and 1 as a progress bar
+// that fills the cell, values outside of the range are clamped and values that are not finite are drawn as 0,
+// the column type has to be float64
+func NewProgressRenderer() CellRenderer {
+	return progressRenderer{}
+}
+
+// Render draws the bar filling the width
+func (progressRenderer) Render(value any, width, _ int) string {
+	ratio, _ := value.(float64)
+	return progressBar(ratio, width)
+}
+
+// Scalar returns the value itself
+func (progressRenderer) Scalar(value any) (any, bool) {
+	ratio, ok := value.(float64)
+	return ratio, ok
+}
+
+// GaugeThreshold styles the gauge when its value reaches From
+type GaugeThreshold struct {
+	From  float64
+	Style lipgloss.Style
+}
+
+// gaugeRenderer draws float64 between 0 and 1 as a bar with a percentage
+type gaugeRenderer struct {
+	thresholds []GaugeThreshold
+}
+
+// NewGaugeRenderer returns a renderer drawing float64 cells between 0 and 1 as a bar followed by
+// the percentage, the bar is styled by the highest threshold the value reaches, the column type has to be float64
+func NewGaugeRenderer(thresholds ...GaugeThreshold) CellRenderer {
+	return gaugeRenderer{thresholds: thresholds}
+}
+
+// Render draws the bar and the percentage, only the percentage if the bar does not fit
+func (g gaugeRenderer) Render(value any, width, _ int) string {
+	ratio, _ := value.(float64)
+	ratio = clampRatio(ratio)
+	label := fmt.Sprintf("%3.0f%%", ratio*100)
+	barWidth := width - len(label) - 1
+	if barWidth < 1 {
+		return label
+	}
+	bar := progressBar(ratio, barWidth)
+	// thresholds are not sorted, the highest one reached wins
+	var style *lipgloss.Style
+	var chosenFrom float64
+	for i, threshold := range g.thresholds {
+		if ratio >= threshold.From && (style == nil || threshold.From > chosenFrom) {
+			style, chosenFrom = &g.thresholds[i].Style, threshold.From
+		}
+	}
+	if style != nil {
+		bar = style.Render(bar)
+	}
+	return bar + " " + label
+}
+
+// Scalar returns the value itself
+func (gaugeRenderer) Scalar(value any) (any, bool) {
+	ratio, ok := value.(float64)
+	return ratio, ok
+}
+
+// checkRenderer draws bool as a check mark
+type checkRenderer struct{}
+
+// NewCheckRenderer returns a renderer drawing bool cells as a check mark or a cross, true cells are sorted
+// after the false ones so the column type has to be int
+func NewCheckRenderer() CellRenderer {
+	return checkRenderer{}
+}
+
+// Render draws the mark
+func (checkRenderer) Render(value any, _, _ int) string {
+	if checked, _ := value.(bool); checked {
+		return tableCheckTrue
+	}
+	return tableCheckFalse
+}
+
+// Scalar returns 1 for true and 0 for false
+func (checkRenderer) Scalar(value any) (any, bool) {
+	checked, ok := value.(bool)
+	if !ok {
+		return nil, false
+	}
+	if checked {
+		return 1, true
+	}
+	return 0, true
+}
+
+// progressBar draws the ratio as a bar of the width, partially filled cell is drawn with eighth blocks
+func progressBar(ratio float64, width int) string {
+	if width < 1 {
+		return ""
+	}
+	ratio = clampRatio(ratio)
+	eighths := int(math.Round(ratio * float64(width*8)))
+	full, partial := eighths/8, eighths%8
+	bar := strings.Repeat(string(tableProgressChars[8]), full)
+	if partial > 0 {
+		bar += string(tableProgressChars[partial])
+		full++
+	}
+	return bar + strings.Repeat(tableProgressEmptyChar, width-full)
+}
+
+// clampRatio clamps the ratio between 0 and 1, values that are not finite are 0
+func clampRatio(ratio float64) float64 {
+	if !isFinite(ratio) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, ratio))
+}
+
+// isFinite reports whether the value is neither NaN nor infinite
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,137 @@
+package table
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestProgressRenderer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		width int
+		want  string
+	}{
+		{"empty", 0, 4, "░░░░"},
+		{"half", 0.5, 4, "██░░"},
+		{"partial eighth", 0.5, 3, "█▌░"},
+		{"full", 1, 4, "████"},
+		{"below the range", -2, 4, "░░░░"},
+		{"above the range", 3, 4, "████"},
+		{"nan", math.NaN(), 4, "░░░░"},
+		{"positive infinity", math.Inf(1), 4, "░░░░"},
+		{"negative infinity", math.Inf(-1), 4, "░░░░"},
+		{"zero width", 0.5, 0, ""},
+		{"negative width", 0.5, -3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProgressRenderer().Render(tt.value, tt.width, 1); got != tt.want {
+				t.Errorf("Render(%v, %d) = %q, want %q", tt.value, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGaugeRenderer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		width int
+		want  string
+	}{
+		{"bar and label", 0.5, 9, "██░░  50%"},
+		{"label only when the bar does not fit", 0.5, 5, " 50%"},
+		{"zero width", 0.5, 0, " 50%"},
+		{"below the range", -1, 9, "░░░░   0%"},
+		{"above the range", 2, 9, "████ 100%"},
+		{"nan", math.NaN(), 9, "░░░░   0%"},
+		{"positive infinity", math.Inf(1), 9, "░░░░   0%"},
+		{"negative infinity", math.Inf(-1), 9, "░░░░   0%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi.Strip(NewGaugeRenderer().Render(tt.value, tt.width, 1)); got != tt.want {
+				t.Errorf("Render(%v, %d) = %q, want %q", tt.value, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGaugeRendererPicksHighestThreshold(t *testing.T) {
+	low := lipgloss.NewStyle().SetString("low")
+	high := lipgloss.NewStyle().SetString("high")
+	// thresholds are not sorted, the highest one the value reaches wins
+	gauge := NewGaugeRenderer(GaugeThreshold{From: 0.8, Style: high}, GaugeThreshold{From: 0.2, Style: low})
+	if got := gauge.Render(0.9, 9, 1); !strings.HasPrefix(got, "high") {
+		t.Errorf("0.9 rendered %q, want the high style", got)
+	}
+	if got := gauge.Render(0.5, 9, 1); !strings.HasPrefix(got, "low") {
+		t.Errorf("0.5 rendered %q, want the low style", got)
+	}
+	if got := gauge.Render(math.NaN(), 9, 1); strings.HasPrefix(got, "low") || strings.HasPrefix(got, "high") {
+		t.Errorf("nan rendered %q, want no threshold style", got)
+	}
+}
+
+func TestSparklineRenderer(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"scaled between low and high", []float64{0, 7, 3.5}, 10, "▁█▄"},
+		{"flat", []float64{2, 2}, 10, "▅▅"},
+		{"latest values that fit", []float64{0, 1, 2, 3}, 2, "▁█"},
+		{"nan left empty", []float64{0, math.NaN(), 7}, 10, "▁ █"},
+		{"infinity left empty", []float64{1, math.Inf(1)}, 10, "▅ "},
+		{"negative infinity left empty", []float64{math.Inf(-1), 0, 7}, 10, " ▁█"},
+		{"only non finite values", []float64{math.NaN(), math.Inf(1)}, 10, "  "},
+		{"zero width", []float64{1, 2}, 0, ""},
+		{"no values", nil, 10, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSparklineRenderer().Render(tt.values, tt.width, 1); got != tt.want {
+				t.Errorf("Render(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRenderer(t *testing.T) {
+	renderer := NewCheckRenderer()
+	if got := renderer.Render(true, 3, 1); got != tableCheckTrue {
+		t.Errorf("true rendered %q", got)
+	}
+	if got := renderer.Render(false, 3, 1); got != tableCheckFalse {
+		t.Errorf("false rendered %q", got)
+	}
+	if scalar, ok := renderer.Scalar(true); !ok || scalar != 1 {
+		t.Errorf("scalar of true = %v, %v, want 1", scalar, ok)
+	}
+	if _, ok := renderer.Scalar("yes"); ok {
+		t.Error("string accepted by the check renderer")
+	}
+}
+
+func TestSetColumnRendererValidatesRows(t *testing.T) {
+	table := NewTable(40, 5, []string{"name", "load"}).
+		MustSetTypes("", 0.0).
+		MustAddRows([][]any{{"a", 0.5}})
+	if _, err := table.SetColumnRenderer(1, NewCheckRenderer()); err == nil {
+		t.Error("check renderer accepted a float64 cell")
+	}
+	if _, err := table.SetColumnRenderer(1, NewProgressRenderer()); err != nil {
+		t.Errorf("progress renderer rejected a float64 cell: %v", err)
+	}
+	// the sparkline scalar is float64 but the cell has to be []float64
+	if _, err := table.SetColumnRenderer(1, NewSparklineRenderer()); err == nil {
+		t.Error("sparkline renderer accepted a float64 cell")
+	}
+}
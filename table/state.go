@@ -0,0 +1,223 @@
+package table
+
+// State is a JSON serializable snapshot of the table view, it can be saved when the app exits
+// and restored with RestoreState, columns are referenced by their header
+type State struct {
+	// Sort is the sort stack, sorts are listed in the order they were applied, last one is active
+	Sort []SortState `json:"sort,omitempty"`
+	// Filters applied to the columns
+	Filters []FilterState `json:"filters,omitempty"`
+	// ColumnOrder display order of the columns, hidden columns included
+	ColumnOrder []string `json:"column_order,omitempty"`
+	// HiddenColumns columns that are not displayed
+	HiddenColumns []string `json:"hidden_columns,omitempty"`
+	// ColumnWidths locked column widths, columns with ratio based width are omitted
+	ColumnWidths map[string]int `json:"column_widths,omitempty"`
+	// CursorColumn column under the cursor
+	CursorColumn string `json:"cursor_column,omitempty"`
+	// CursorRow index of the row under the cursor, within the filtered rows
+	CursorRow int `json:"cursor_row"`
+	// RowsTop index of the top visible row
+	RowsTop int `json:"rows_top"`
+	// ColumnsLeft leftmost visible column
+	ColumnsLeft string `json:"columns_left,omitempty"`
+}
+
+// SortState is a sort applied to the column
+type SortState struct {
+	Column string          `json:"column"`
+	Order  SortingOrderKey `json:"order"`
+}
+
+// FilterState is a filter applied to the column, either a substring filter set with SetFilter,
+// a value filter set with SetValueFilter or a null filter set with SetNullFilter
+type FilterState struct {
+	Column string     `json:"column"`
+	Value  string     `json:"value,omitempty"`
+	Values []string   `json:"values,omitempty"`
+	Empty  NullFilter `json:"empty,omitempty"`
+}
+
+// State returns the snapshot of the current view of the table
+func (r *Table) State() State {
+	state := State{
+		CursorColumn: r.columnHeader(r.cursorColumn()),
+		CursorRow:    r.cursorIndexY,
+		RowsTop:      r.rowsTopIndex,
+	}
+	for _, entry := range r.sortStack {
+		state.Sort = append(state.Sort, SortState{Column: r.columnHeaders[entry.column], Order: entry.order})
+	}
+	if r.filteredColumn >= 0 && r.filterString != "" {
+		state.Filters = append(state.Filters, FilterState{
+			Column: r.columnHeaders[r.filteredColumn],
+			Value:  r.filterString,
+		})
+	}
+	for index := range r.columnHeaders {
+		if values := r.GetValueFilter(index); len(values) > 0 {
+			state.Filters = append(state.Filters, FilterState{Column: r.columnHeaders[index], Values: values})
+		}
+		if filter := r.GetNullFilter(index); filter != NullFilterNone {
+			state.Filters = append(state.Filters, FilterState{Column: r.columnHeaders[index], Empty: filter})
+		}
+	}
+	for _, index := range r.columnOrder {
+		state.ColumnOrder = append(state.ColumnOrder, r.columnHeaders[index])
+		if r.columnHidden[index] {
+			state.HiddenColumns = append(state.HiddenColumns, r.columnHeaders[index])
+		}
+	}
+	for index, width := range r.columnWidth {
+		if width > 0 {
+			if state.ColumnWidths == nil {
+				state.ColumnWidths = make(map[string]int)
+			}
+			state.ColumnWidths[r.columnHeaders[index]] = width
+		}
+	}
+	if r.columnVisibleLeftIndex >= 0 && r.columnVisibleLeftIndex < len(r.columnView) {
+		state.ColumnsLeft = r.columnHeaders[r.columnView[r.columnVisibleLeftIndex]]
+	}
+	return state
+}
+
+// RestoreState applies the snapshot taken with State, entries that refer to columns that
+// no longer exist are skipped, as well as sorts that fail, so the rest of the view is restored.
+// Sorting is replayed on the rows, so restore the state after the rows are added.
+func (r *Table) RestoreState(state State) *Table {
+	// column order, columns missing from the state keep their relative order at the end
+	var order []int
+	seen := make(map[int]bool)
+	for _, header := range state.ColumnOrder {
+		if index := r.columnIndex(header); index >= 0 && !seen[index] {
+			order = append(order, index)
+			seen[index] = true
+		}
+	}
+	for _, index := range r.columnOrder {
+		if !seen[index] {
+			order = append(order, index)
+		}
+	}
+	r.columnOrder = order
+
+	for index := range r.columnHidden {
+		r.columnHidden[index] = false
+	}
+	for _, header := range state.HiddenColumns {
+		if index := r.columnIndex(header); index >= 0 {
+			r.columnHidden[index] = true
+		}
+	}
+
+	for index := range r.columnWidth {
+		r.columnWidth[index] = 0
+	}
+	for header, width := range state.ColumnWidths {
+		if index := r.columnIndex(header); index >= 0 && width > 0 {
+			r.columnWidth[index] = width
+		}
+	}
+
+	// rows are sorted only when the sort stack differs, replaying it on the rows in the order
+	// they were added reproduces the order since sorting is stable
+	var stack []sortEntry
+	for _, sort := range state.Sort {
+		if index := r.columnIndex(sort.Column); index >= 0 {
+			stack = append(stack, sortEntry{column: index, order: sort.Order})
+		}
+	}
+	if !equalSortStacks(stack, r.sortStack) {
+		r.rows = r.rowsInSequence(r.rows)
+		r.sortStack = nil
+		r.orderedColumnIndex = -1
+		for _, entry := range stack {
+			_, _ = r.orderBy(entry.column, entry.order)
+		}
+	}
+
+	r.filterString, r.filteredColumn = "", -1
+	r.valueFilters = nil
+	r.nullFilters = nil
+	for _, filter := range state.Filters {
+		index := r.columnIndex(filter.Column)
+		if index < 0 {
+			continue
+		}
+		if filter.Value != "" {
+			r.filteredColumn, r.filterString = index, filter.Value
+		}
+		if filter.Empty != NullFilterNone {
+			if r.nullFilters == nil {
+				r.nullFilters = make(map[int]NullFilter)
+			}
+			r.nullFilters[index] = filter.Empty
+		}
+		if len(filter.Values) > 0 {
+			if r.valueFilters == nil {
+				r.valueFilters = make(map[int]map[string]struct{})
+			}
+			r.valueFilters[index] = make(map[string]struct{}, len(filter.Values))
+			for _, value := range filter.Values {
+				r.valueFilters[index][value] = struct{}{}
+			}
+		}
+	}
+	r.applyFilter()
+
+	// cursor and scroll offsets
+	r.updateColumnView()
+	// cursor column missing from the state falls back to the first displayed column
+	r.cursorIndexX = 0
+	if position := r.columnPosition(r.columnIndex(state.CursorColumn)); position >= 0 {
+		r.cursorIndexX = position
+	}
+	r.cursorIndexY = clamp(state.CursorRow, 0, len(r.filteredRows)-1)
+	r.rowsTopIndex = clamp(state.RowsTop, 0, r.cursorIndexY)
+	r.setTopRow()
+
+	r.recalculateVisibleColumnRange()
+	if left := r.columnPosition(r.columnIndex(state.ColumnsLeft)); left >= 0 && left <= r.cursorIndexX {
+		right, _ := r.columnIndexSeekRight(left, 0)
+		if r.cursorIndexX <= right {
+			r.columnVisibleLeftIndex, r.columnVisibleRightIndex = left, right
+		}
+	}
+
+	r.setRowsUpdate()
+	r.setHeadersUpdate()
+	return r
+}
+
+// columnIndex returns the index of the first column with the header, -1 if there is none
+func (r *Table) columnIndex(header string) int {
+	if header == "" {
+		return -1
+	}
+	for index, h := range r.columnHeaders {
+		if h == header {
+			return index
+		}
+	}
+	return -1
+}
+
+// columnHeader returns the header of the column with index n, empty string if there is none
+func (r *Table) columnHeader(index int) string {
+	if index < 0 || index >= len(r.columnHeaders) {
+		return ""
+	}
+	return r.columnHeaders[index]
+}
+
+// clamp limits the value to the low/high range, low wins if the range is empty
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
@@ -0,0 +1,99 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func newStateTable() *Table {
+	var rows [][]any
+	for i := 0; i < 20; i++ {
+		var count any = i % 7
+		if i%5 == 0 {
+			count = nil
+		}
+		rows = append(rows, []any{fmt.Sprintf("row %02d", i), []string{"x", "y"}[i%2], count})
+	}
+	return NewTable(60, 10, []string{"name", "kind", "count"}).
+		MustSetTypes("", "", 0).
+		MustAddRows(rows)
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	table := newStateTable().
+		MustOrderByAsc(0).
+		MustOrderByDesc(2).
+		MustSetFilter(0, "row").
+		MustSetValueFilter(1, "x").
+		MustSetNullFilter(2, NullFilterNotEmpty).
+		MustSetColumnWidth(0, 12)
+	if _, err := table.MoveColumn(2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.HideColumn(1); err != nil {
+		t.Fatal(err)
+	}
+	table.CursorRight()
+	for i := 0; i < 6; i++ {
+		table.CursorDown()
+	}
+	table.Render()
+	saved := table.State()
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var loaded State
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	restored := newStateTable().RestoreState(loaded)
+	restored.Render()
+
+	if got := restored.State(); !reflect.DeepEqual(got, saved) {
+		t.Errorf("restored state\n%+v\nwant\n%+v", got, saved)
+	}
+	if got, want := names(restored), names(table); got != want {
+		t.Errorf("restored rows %s, want %s", got, want)
+	}
+	if got, want := restored.GetCursorValue(), table.GetCursorValue(); got != want {
+		t.Errorf("cursor on %q, want %q", got, want)
+	}
+	if restored.rowsTopIndex != table.rowsTopIndex {
+		t.Errorf("top row %d, want %d", restored.rowsTopIndex, table.rowsTopIndex)
+	}
+}
+
+func TestRestoreStateSkipsUnknownHeaders(t *testing.T) {
+	state := State{
+		Sort:          []SortState{{Column: "missing", Order: SortingOrderAscending}, {Column: "name", Order: SortingOrderAscending}},
+		Filters:       []FilterState{{Column: "missing", Value: "x"}, {Column: "kind", Values: []string{"y"}}},
+		ColumnOrder:   []string{"missing", "count", "name"},
+		HiddenColumns: []string{"missing"},
+		ColumnWidths:  map[string]int{"missing": 5, "count": 4},
+		CursorColumn:  "missing",
+		ColumnsLeft:   "missing",
+	}
+	table := newStateTable().RestoreState(state)
+	if order := fmt.Sprint(table.GetColumnOrder()); order != "[2 0 1]" {
+		t.Errorf("column order %s, want known columns first and the rest after", order)
+	}
+	if len(table.sortStack) != 1 || table.sortStack[0].column != 0 {
+		t.Errorf("sort stack %v, want only the name sort", table.sortStack)
+	}
+	if values := table.GetValueFilter(1); fmt.Sprint(values) != "[y]" {
+		t.Errorf("value filter %v, want [y]", values)
+	}
+	if column, s := table.GetFilter(); column != -1 || s != "" {
+		t.Errorf("filter %d %q, want none", column, s)
+	}
+	if width := table.GetColumnWidth(2); width != 4 {
+		t.Errorf("count width %d, want 4", width)
+	}
+	if x, y := table.GetCursorLocation(); x != 2 || y != 0 {
+		t.Errorf("cursor %d,%d, want it on the first displayed column", x, y)
+	}
+}
@@ -0,0 +1,233 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// tableDefaultStatsBins number of histogram bins computed by ColumnStats
+const tableDefaultStatsBins = 16
+
+// tableStatsHistogramChars bars of the histogram from the lowest to the highest
+var tableStatsHistogramChars = []rune("▁▂▃▄▅▆▇█")
+
+// StatsKeyMap keys showing and hiding the statistics panel, matched against tea.KeyMsg.String()
+type StatsKeyMap struct {
+	Toggle []string
+}
+
+// DefaultStatsKeyMap returns the default statistics panel key map
+func DefaultStatsKeyMap() StatsKeyMap {
+	return StatsKeyMap{
+		Toggle: []string{"ctrl+t"},
+	}
+}
+
+// ColumnStats summary of the numeric column over the filtered rows
+type ColumnStats struct {
+	// Count number of cells holding a value
+	Count int
+	// Nulls number of cells holding no value
+	Nulls  int
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	P90    float64
+	P99    float64
+	// StdDev population standard deviation
+	StdDev float64
+	// Histogram number of values in equal width bins between Min and Max
+	Histogram []int
+}
+
+// ColumnStats computes the summary of the column with index n over the filtered rows,
+// returns an error if the column is not of one of the int or float types
+func (r *Table) ColumnStats(index int) (ColumnStats, error) {
+	var stats ColumnStats
+	if err := r.checkColumnIndex(index); err != nil {
+		return stats, err
+	}
+	if _, ok := toFloat(r.columnTypeAt(index)); !ok {
+		message := fmt.Sprintf(
+			"column of type %v on index %d is not numeric", reflect.TypeOf(r.columnTypeAt(index)), index,
+		)
+		return stats, ErrorBadType{msg: message}
+	}
+
+	var values []float64
+	for _, row := range r.filteredRows {
+		value, ok := toFloat(r.cellValue(row, index))
+		if !ok {
+			stats.Nulls++
+			continue
+		}
+		values = append(values, value)
+	}
+	stats.Count = len(values)
+	stats.Histogram = make([]int, tableDefaultStatsBins)
+	if stats.Count == 0 {
+		return stats, nil
+	}
+
+	sort.Float64s(values)
+	stats.Min, stats.Max = values[0], values[len(values)-1]
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	stats.Mean = sum / float64(stats.Count)
+	var squares float64
+	for _, value := range values {
+		squares += (value - stats.Mean) * (value - stats.Mean)
+	}
+	stats.StdDev = math.Sqrt(squares / float64(stats.Count))
+	stats.Median = percentile(values, 0.5)
+	stats.P90 = percentile(values, 0.9)
+	stats.P99 = percentile(values, 0.99)
+
+	spread := stats.Max - stats.Min
+	for _, value := range values {
+		bin := 0
+		if spread > 0 {
+			bin = int((value - stats.Min) / spread * tableDefaultStatsBins)
+		}
+		// max value closes the last bin
+		if bin >= tableDefaultStatsBins {
+			bin = tableDefaultStatsBins - 1
+		}
+		stats.Histogram[bin]++
+	}
+	return stats, nil
+}
+
+// ToggleStatsPanel shows or hides the statistics of the column under the cursor over the rows,
+// the panel follows the cursor and the filters while it is shown, Update toggles it with the keys of StatsKeyMap
+func (r *Table) ToggleStatsPanel() *Table {
+	r.statsPanelOpen = !r.statsPanelOpen
+	r.setRowsUpdate()
+	return r
+}
+
+// SetStatsKeyMap replaces the keys showing and hiding the statistics panel
+func (r *Table) SetStatsKeyMap(keyMap StatsKeyMap) *Table {
+	r.statsKeyMap = keyMap
+	return r
+}
+
+// IsStatsPanelOpen reports whether the statistics panel is shown
+func (r *Table) IsStatsPanelOpen() bool {
+	return r.statsPanelOpen
+}
+
+// renderStatsPanel renders the statistics panel of the column under the cursor placed over the rows box
+func (r *Table) renderStatsPanel() string {
+	index := r.cursorColumn()
+	var lines []string
+	if index < 0 {
+		lines = append(lines, "no column under the cursor")
+	} else {
+		lines = append(lines, lipgloss.NewStyle().Bold(true).Render(r.columnHeaders[index]))
+		stats, err := r.ColumnStats(index)
+		if err != nil {
+			lines = append(lines, "not a numeric column")
+		} else {
+			lines = append(lines, statsTable([][2]string{
+				{"count", strconv.Itoa(stats.Count)}, {"nulls", strconv.Itoa(stats.Nulls)},
+				{"min", formatStat(stats.Min)}, {"max", formatStat(stats.Max)},
+				{"mean", formatStat(stats.Mean)}, {"median", formatStat(stats.Median)},
+				{"p90", formatStat(stats.P90)}, {"p99", formatStat(stats.P99)},
+				{"stddev", formatStat(stats.StdDev)},
+			})...)
+			if stats.Count > 0 {
+				lines = append(lines, "", histogram(stats.Histogram))
+			}
+		}
+	}
+	// the panel has border and padding on both sides
+	innerWidth := int(math.Max(1, float64(r.width-4)))
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, innerWidth, r.ellipsis)
+	}
+	// panel taller than the rows box is cut so the table keeps its height
+	panel := r.styles[StyleKeyStats].MaxHeight(r.rowsBox.GetHeight()).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(r.width, r.rowsBox.GetHeight(), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// statsTable lays out the label/value pairs in two columns
+func statsTable(pairs [][2]string) []string {
+	var labelWidth, valueWidth int
+	for _, pair := range pairs {
+		labelWidth = int(math.Max(float64(labelWidth), float64(len(pair[0]))))
+		valueWidth = int(math.Max(float64(valueWidth), float64(len(pair[1]))))
+	}
+	var lines []string
+	for i := 0; i < len(pairs); i += 2 {
+		line := fmt.Sprintf("%-*s %*s", labelWidth, pairs[i][0], valueWidth, pairs[i][1])
+		if i+1 < len(pairs) {
+			line += fmt.Sprintf("   %-*s %*s", labelWidth, pairs[i+1][0], valueWidth, pairs[i+1][1])
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// histogram renders the bins as bars scaled to the largest bin, empty bins are blank
+func histogram(bins []int) string {
+	var highest int
+	for _, bin := range bins {
+		highest = int(math.Max(float64(highest), float64(bin)))
+	}
+	var s strings.Builder
+	for _, bin := range bins {
+		if bin == 0 || highest == 0 {
+			s.WriteRune(' ')
+			continue
+		}
+		level := int(math.Ceil(float64(bin)/float64(highest)*float64(len(tableStatsHistogramChars)))) - 1
+		s.WriteRune(tableStatsHistogramChars[level])
+	}
+	return s.String()
+}
+
+// percentile returns the p-th percentile of the sorted values, interpolated between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	position := p * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+// formatStat formats the statistic with up to six significant digits
+func formatStat(value float64) string {
+	return strconv.FormatFloat(value, 'g', 6, 64)
+}
+
+// toFloat converts the numeric Ordered value to float64, reports false for any other value
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,54 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestStatsKeyTogglesPanel(t *testing.T) {
+	table := NewTable(40, 20, []string{"name", "load"}).
+		MustSetTypes("", 0).
+		MustAddRows([][]any{{"a", 1}, {"b", 3}})
+	table.CursorRight()
+	toggle := tea.KeyMsg{Type: tea.KeyCtrlT}
+	table, _ = table.Update(toggle)
+	if !table.IsStatsPanelOpen() {
+		t.Fatal("stats panel not opened by the key")
+	}
+	if rendered := ansi.Strip(table.Render()); !strings.Contains(rendered, "mean") {
+		t.Errorf("stats panel not rendered:\n%s", rendered)
+	}
+	table, _ = table.Update(toggle)
+	if table.IsStatsPanelOpen() {
+		t.Error("stats panel not closed by the key")
+	}
+
+	table.SetStatsKeyMap(StatsKeyMap{Toggle: []string{"s"}})
+	table, _ = table.Update(toggle)
+	if table.IsStatsPanelOpen() {
+		t.Error("replaced key still toggles the panel")
+	}
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if !table.IsStatsPanelOpen() {
+		t.Error("stats panel not opened by the key from the key map")
+	}
+}
+
+func TestColumnStats(t *testing.T) {
+	table := NewTable(40, 20, []string{"load"}).
+		MustSetTypes(0).
+		MustAddRows([][]any{{1}, {2}, {3}, {4}, {nil}})
+	stats, err := table.ColumnStats(0)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Count != 4 || stats.Nulls != 1 || stats.Min != 1 || stats.Max != 4 || stats.Mean != 2.5 {
+		t.Errorf("stats %+v, want count 4, nulls 1, min 1, max 4 and mean 2.5", stats)
+	}
+	if _, err := NewTable(40, 20, []string{"name"}).ColumnStats(0); err == nil {
+		t.Error("stats of a string column did not fail")
+	}
+}
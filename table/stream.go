@@ -0,0 +1,255 @@
+package table
+
+import (
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// tableDefaultStreamBatchSize is the maximum number of rows read from a stream into a single update
+	tableDefaultStreamBatchSize = 10000
+	// tableDefaultStreamBatchInterval is how long a stream waits for more rows before flushing a batch
+	tableDefaultStreamBatchInterval = 16 * time.Millisecond
+)
+
+// StreamMsg carries a batch of rows read from a stream, it has to be passed to Table.Update
+type StreamMsg struct {
+	table  *Table
+	stream <-chan []any
+	rows   [][]any
+	closed bool
+}
+
+// StreamErrorMsg is emitted when rows read from a stream do not match the table types,
+// invalid rows are dropped and the stream keeps going, Err holds the first error of the batch
+type StreamErrorMsg struct {
+	Err error
+}
+
+// StreamClosedMsg is emitted once the stream channel is closed and all of its rows are added
+type StreamClosedMsg struct{}
+
+// Stream returns a command that subscribes the table to the channel, rows are read in batches,
+// everything that arrives within the batch interval ends up in a single StreamMsg so a burst of
+// rows produces one re-render. Table.Update handles the message and returns the command that
+// reads the next batch, so all the messages have to be routed to Table.Update.
+func (r *Table) Stream(ch <-chan []any) tea.Cmd {
+	batchSize, batchInterval := r.streamBatchSize, r.streamBatchInterval
+	return func() tea.Msg {
+		return readStream(r, ch, batchSize, batchInterval)
+	}
+}
+
+// SetStreamBatching sets the maximum number of rows in a batch and how long the stream waits
+// for the batch to fill up before it is flushed, it applies to streams started afterwards
+func (r *Table) SetStreamBatching(size int, interval time.Duration) *Table {
+	if size < 1 {
+		size = 1
+	}
+	r.streamBatchSize = size
+	r.streamBatchInterval = interval
+	return r
+}
+
+// SetMaxRows caps the number of rows the table holds, when exceeded the oldest rows are dropped
+// as in a ring buffer and the history is cleared, 0 disables the cap
+func (r *Table) SetMaxRows(value int) *Table {
+	if value < 0 {
+		value = 0
+	}
+	r.maxRows = value
+	if value > 0 {
+		r.trimRows()
+		r.applyFilter()
+		r.setTopRow()
+		r.setRowsUpdate()
+	}
+	return r
+}
+
+// SetFollowTail enables the "follow tail" mode, while the cursor is on the last row it stays
+// pinned to the last row as new rows are added, moving the cursor up pauses the following
+// and moving it back to the bottom resumes it
+func (r *Table) SetFollowTail(value bool) *Table {
+	r.followTail = value
+	return r
+}
+
+// handleStream adds the valid rows of the batch and schedules reading of the next one
+func (r *Table) handleStream(msg StreamMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	var valid [][]any
+	var firstErr error
+	for _, row := range msg.rows {
+		if err := r.validateRow(row...); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		valid = append(valid, row)
+	}
+	if len(valid) > 0 {
+		r.appendRows(valid)
+	}
+	if firstErr != nil {
+		cmds = append(cmds, func() tea.Msg { return StreamErrorMsg{Err: firstErr} })
+	}
+	if msg.closed {
+		cmds = append(cmds, func() tea.Msg { return StreamClosedMsg{} })
+	} else {
+		batchSize, batchInterval := r.streamBatchSize, r.streamBatchInterval
+		cmds = append(cmds, func() tea.Msg {
+			return readStream(r, msg.stream, batchSize, batchInterval)
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// readStream blocks until the first row arrives, then it keeps collecting rows until the batch
+// is full, the interval runs out or the channel is closed
+func readStream(r *Table, ch <-chan []any, batchSize int, batchInterval time.Duration) tea.Msg {
+	msg := StreamMsg{table: r, stream: ch}
+	row, ok := <-ch
+	if !ok {
+		msg.closed = true
+		return msg
+	}
+	msg.rows = append(msg.rows, row)
+
+	deadline := time.NewTimer(batchInterval)
+	defer deadline.Stop()
+	for len(msg.rows) < batchSize {
+		select {
+		case row, ok := <-ch:
+			if !ok {
+				msg.closed = true
+				return msg
+			}
+			msg.rows = append(msg.rows, row)
+		case <-deadline.C:
+			return msg
+		}
+	}
+	return msg
+}
+
+// appendRows appends already validated rows, trims the rows to the cap and keeps the cursor
+// pinned to the last row when following the tail
+func (r *Table) appendRows(rows [][]any) {
+	following := r.followTail && r.cursorIndexY >= len(r.filteredRows)-1
+	var cursorRow []any
+	if !following && r.cursorIndexY < len(r.filteredRows) {
+		cursorRow = r.filteredRows[r.cursorIndexY]
+	}
+
+	r.rows = append(r.rows, r.ownRows(rows)...)
+	if r.maxRows > 0 {
+		r.trimRows()
+	}
+	r.applyFilter()
+
+	if following {
+		r.cursorIndexY = len(r.filteredRows) - 1
+	} else if cursorRow != nil && r.maxRows > 0 {
+		// trimming shifts the rows, keep the cursor on the same row if it survived
+		r.cursorIndexY = 0
+		for i, row := range r.filteredRows {
+			if rowAddress(row) == rowAddress(cursorRow) {
+				r.cursorIndexY = i
+				break
+			}
+		}
+	}
+	if r.cursorIndexY < 0 {
+		r.cursorIndexY = 0
+	}
+	r.setTopRow()
+	r.setRowsUpdate()
+}
+
+// ownRows copies the rows into arrays owned by the table and gives each of them an id, so rows sharing
+// a backing array or a buffer reused by the caller are told apart
+func (r *Table) ownRows(rows [][]any) [][]any {
+	owned := make([][]any, 0, len(rows))
+	for _, row := range rows {
+		// capacity of at least one gives empty rows an address of their own
+		ownedRow := make([]any, len(row), max(len(row), 1))
+		copy(ownedRow, row)
+		r.nextRowID++
+		r.rowIDs[rowAddress(ownedRow)] = r.nextRowID
+		owned = append(owned, ownedRow)
+	}
+	return owned
+}
+
+// rowID returns the id given to the row when it was added, ids increase in the order the rows are added,
+// 0 if the row is not held by the table
+func (r *Table) rowID(row []any) uint64 {
+	return r.rowIDs[rowAddress(row)]
+}
+
+// trimRows drops the oldest rows over the cap, rows might be sorted so age is taken from the ids
+func (r *Table) trimRows() {
+	excess := len(r.rows) - r.maxRows
+	if r.maxRows == 0 || excess <= 0 {
+		return
+	}
+	// recorded operations might refer to the dropped rows
+	r.ClearHistory()
+	ids := make([]uint64, 0, len(r.rows))
+	for _, row := range r.rows {
+		ids = append(ids, r.rowID(row))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	threshold := ids[excess-1]
+
+	kept := make([][]any, 0, r.maxRows)
+	for _, row := range r.rows {
+		if r.rowID(row) <= threshold {
+			r.forgetRow(row)
+			continue
+		}
+		kept = append(kept, row)
+	}
+	r.rows = kept
+}
+
+// rowsInSequence returns the rows in the order they were added, rows without an id go first
+func (r *Table) rowsInSequence(rows [][]any) [][]any {
+	ordered := append([][]any(nil), rows...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return r.rowID(ordered[i]) < r.rowID(ordered[j])
+	})
+	return ordered
+}
+
+// rowsInOrder returns the rows ordered as in the given order, rows missing from it keep their relative order at the end
+func (r *Table) rowsInOrder(order [][]any) [][]any {
+	positions := make(map[*any]int, len(order))
+	for i, row := range order {
+		positions[rowAddress(row)] = i
+	}
+	position := func(row []any) int {
+		if i, ok := positions[rowAddress(row)]; ok {
+			return i
+		}
+		return len(order)
+	}
+	ordered := append([][]any(nil), r.rows...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return position(ordered[i]) < position(ordered[j])
+	})
+	return ordered
+}
+
+// rowAddress returns the address of the array backing the row, it stays the same while rows are reordered
+// by sorting and filtering, rows held by the table are copied into arrays of their own by ownRows
+func rowAddress(row []any) *any {
+	if cap(row) == 0 {
+		return nil
+	}
+	return &row[:1][0]
+}
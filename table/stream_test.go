@@ -0,0 +1,165 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runStream passes the messages of the command to the table until the stream is closed,
+// returns the number of rows in each StreamMsg
+func runStream(t *testing.T, table *Table, cmd tea.Cmd) []int {
+	t.Helper()
+	var batches []int
+	pending := []tea.Cmd{cmd}
+	for len(pending) > 0 {
+		cmd, pending = pending[0], pending[1:]
+		if cmd == nil {
+			continue
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			pending = append(pending, msg...)
+		case StreamMsg:
+			if len(msg.rows) > 0 {
+				batches = append(batches, len(msg.rows))
+			}
+			var next tea.Cmd
+			table, next = table.Update(msg)
+			pending = append(pending, next)
+		case StreamErrorMsg:
+			t.Errorf("stream error: %v", msg.Err)
+		}
+	}
+	return batches
+}
+
+func newStreamTable() *Table {
+	return NewTable(40, 10, []string{"name"}).SetStreamBatching(3, time.Second)
+}
+
+func TestStreamBatches(t *testing.T) {
+	table := newStreamTable()
+	ch := make(chan []any, 5)
+	for i := 0; i < 5; i++ {
+		ch <- []any{fmt.Sprintf("row %d", i)}
+	}
+	close(ch)
+	batches := runStream(t, table, table.Stream(ch))
+	if fmt.Sprint(batches) != "[3 2]" {
+		t.Errorf("batches %v, want [3 2]", batches)
+	}
+	if len(table.rows) != 5 || table.rows[4][0] != "row 4" {
+		t.Errorf("rows %v, want rows 0-4 in order", table.rows)
+	}
+}
+
+func TestStreamFlushesAfterInterval(t *testing.T) {
+	table := NewTable(40, 10, []string{"name"}).SetStreamBatching(100, 10*time.Millisecond)
+	ch := make(chan []any, 2)
+	ch <- []any{"a"}
+	ch <- []any{"b"}
+	msg, ok := table.Stream(ch)().(StreamMsg)
+	if !ok || len(msg.rows) != 2 || msg.closed {
+		t.Errorf("got %+v, want a batch of 2 rows flushed before the stream is closed", msg)
+	}
+}
+
+func TestStreamDropsInvalidRows(t *testing.T) {
+	table := newStreamTable().MustSetTypes(0)
+	ch := make(chan []any, 3)
+	ch <- []any{1}
+	ch <- []any{"bad"}
+	ch <- []any{3}
+	close(ch)
+	_, cmd := table.Update(table.Stream(ch)())
+	var gotErr bool
+	for _, cmd := range cmd().(tea.BatchMsg) {
+		if _, ok := cmd().(StreamErrorMsg); ok {
+			gotErr = true
+		}
+	}
+	if !gotErr || len(table.rows) != 2 {
+		t.Errorf("error emitted %v with %d rows, want an error and 2 rows", gotErr, len(table.rows))
+	}
+}
+
+func TestMaxRowsDropsOldest(t *testing.T) {
+	table := newStreamTable().SetMaxRows(3)
+	for i := 0; i < 5; i++ {
+		table.MustAddRows([][]any{{fmt.Sprintf("row %d", i)}})
+	}
+	if fmt.Sprint(table.rows) != "[[row 2] [row 3] [row 4]]" {
+		t.Errorf("rows %v, want the 3 newest", table.rows)
+	}
+
+	// the oldest rows are dropped even if sorting moved them to the end
+	table = newStreamTable().MustAddRows([][]any{{"a"}, {"c"}, {"b"}}).MustOrderByAsc(0)
+	table.SetMaxRows(2)
+	if fmt.Sprint(table.rows) != "[[c] [b]]" {
+		t.Errorf("rows %v, want a dropped", table.rows)
+	}
+}
+
+func TestRowsReusingBuffer(t *testing.T) {
+	table := newStreamTable().SetMaxRows(2)
+	buffer := []any{""}
+	for i := 0; i < 4; i++ {
+		buffer[0] = fmt.Sprintf("row %d", i)
+		table.MustAddRows([][]any{buffer})
+	}
+	if fmt.Sprint(table.rows) != "[[row 2] [row 3]]" {
+		t.Errorf("rows %v, want the 2 newest", table.rows)
+	}
+
+	// the same slice added twice is two rows
+	shared := []any{"same"}
+	table = newStreamTable().MustAddRows([][]any{shared, shared})
+	table.ToggleRowSelection()
+	if selected := table.GetSelectedRows(); len(selected) != 1 {
+		t.Errorf("selected %d rows, want only the row under the cursor", len(selected))
+	}
+	if _, err := table.RemoveRow(1); err != nil || len(table.rows) != 1 || !table.isRowSelected(table.rows[0]) {
+		t.Errorf("removing the second row removed the selected one, err %v", err)
+	}
+}
+
+func TestEmptyRowsHaveOwnIDs(t *testing.T) {
+	table := NewTable(40, 10, nil)
+	rows := table.ownRows([][]any{{}, {}})
+	if first, second := table.rowID(rows[0]), table.rowID(rows[1]); first == 0 || first == second {
+		t.Errorf("ids %d and %d, want distinct ids", first, second)
+	}
+}
+
+func TestFollowTail(t *testing.T) {
+	table := newStreamTable().SetFollowTail(true).MustAddRows([][]any{{"a"}, {"b"}})
+	table.CursorDown()
+	table.MustAddRows([][]any{{"c"}, {"d"}})
+	if _, y := table.GetCursorLocation(); y != 3 {
+		t.Fatalf("cursor on %d, want it pinned to the last row 3", y)
+	}
+	// moving up pauses the following
+	table.CursorUp()
+	table.MustAddRows([][]any{{"e"}})
+	if table.GetCursorValue() != "c" {
+		t.Errorf("cursor on %q, want it to stay on c", table.GetCursorValue())
+	}
+	// back at the bottom it follows again
+	table.CursorDown().CursorDown()
+	table.MustAddRows([][]any{{"f"}})
+	if table.GetCursorValue() != "f" {
+		t.Errorf("cursor on %q, want it on the new last row f", table.GetCursorValue())
+	}
+}
+
+func TestMaxRowsKeepsCursorRow(t *testing.T) {
+	table := newStreamTable().SetMaxRows(3).MustAddRows([][]any{{"a"}, {"b"}, {"c"}})
+	table.CursorDown().CursorDown()
+	table.MustAddRows([][]any{{"d"}})
+	if table.GetCursorValue() != "c" {
+		t.Errorf("cursor on %q, want it to stay on c after the trim", table.GetCursorValue())
+	}
+}
@@ -1,16 +1,17 @@
 package table
 
 import (
-	"errors"
 	"fmt"
-	"log"
+	"io"
 	"math"
 	"reflect"
 	"strings"
-	"unicode/utf8"
+	"time"
 
 	"github.com/x85446/stickers/flexbox"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 var (
@@ -31,10 +32,30 @@ var (
 	tableDefaultCellCursorStyle = lipgloss.NewStyle().
 		Background(lipgloss.Color("#f6e58d")).
 		Foreground(lipgloss.Color("#000000"))
+	tableDefaultColumnCursorStyle = tableDefaultCellCursorStyle
+	tableDefaultRowsSelectedStyle = lipgloss.NewStyle().
+					Background(lipgloss.Color("#2d98da")).
+					Foreground(lipgloss.Color("#ffffff"))
+	tableDefaultFacetStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#7158e2")).
+				Padding(0, 1)
+	tableDefaultFacetCursorStyle = tableDefaultCellCursorStyle
+	tableDefaultStatsStyle       = tableDefaultFacetStyle
+	tableDefaultCellChangedStyle = lipgloss.NewStyle().
+					Background(lipgloss.Color("#778ca3")).
+					Foreground(lipgloss.Color("#ffffff"))
+	tableDefaultCellIncreasedStyle = lipgloss.NewStyle().
+					Background(lipgloss.Color("#20bf6b")).
+					Foreground(lipgloss.Color("#ffffff"))
+	tableDefaultCellDecreasedStyle = lipgloss.NewStyle().
+					Background(lipgloss.Color("#eb3b5a")).
+					Foreground(lipgloss.Color("#ffffff"))
 
 	tableDefaultSortAscChar  = "▲"
 	tableDefaultSortDescChar = "▼"
 	tableDefaultFilterChar   = "⑂"
+	tableDefaultEllipsis     = "…"
 
 	tableDefaultStyles = map[StyleKey]lipgloss.Style{
 		StyleKeyHeader:         tableDefaultHeaderStyle,
@@ -43,6 +64,14 @@ var (
 		StyleKeyRowsSubsequent: tableDefaultRowsSubsequentStyle,
 		StyleKeyRowsCursor:     tableDefaultRowsCursorStyle,
 		StyleKeyCellCursor:     tableDefaultCellCursorStyle,
+		StyleKeyColumnCursor:   tableDefaultColumnCursorStyle,
+		StyleKeyRowsSelected:   tableDefaultRowsSelectedStyle,
+		StyleKeyFacet:          tableDefaultFacetStyle,
+		StyleKeyFacetCursor:    tableDefaultFacetCursorStyle,
+		StyleKeyStats:          tableDefaultStatsStyle,
+		StyleKeyCellChanged:    tableDefaultCellChangedStyle,
+		StyleKeyCellIncreased:  tableDefaultCellIncreasedStyle,
+		StyleKeyCellDecreased:  tableDefaultCellDecreasedStyle,
 	}
 )
 
@@ -55,6 +84,14 @@ const (
 	StyleKeyRowsSubsequent
 	StyleKeyRowsCursor
 	StyleKeyCellCursor
+	StyleKeyColumnCursor
+	StyleKeyRowsSelected
+	StyleKeyFacet
+	StyleKeyFacetCursor
+	StyleKeyStats
+	StyleKeyCellChanged
+	StyleKeyCellIncreased
+	StyleKeyCellDecreased
 )
 
 // Table responsive, x/y scrollable table that uses magic of FlexBox
@@ -65,6 +102,15 @@ type Table struct {
 	// TODO: change type to uint8
 	// columnMinWidth minimal width of the column
 	columnMinWidth []int
+	// columnWidth locked width of the column, 0 means width is calculated from the ratio
+	columnWidth []int
+	// columnOrder display order of the columns, as indexes of the column headers
+	columnOrder []int
+	// columnHidden marks the columns that are not displayed
+	columnHidden []bool
+	// columnView indexes of the displayed columns in the display order, cursor x and the
+	// visible column range are positions within this slice
+	columnView []int
 
 	// columnHeaders column text headers
 	// TODO: make this optional, as well as footer
@@ -72,10 +118,60 @@ type Table struct {
 	columnType    []any
 	rows          [][]any
 
+	// computedColumns virtual columns placed after the regular ones, see AddComputedColumn
+	computedColumns []computedColumn
+	// computedValues cache of the evaluated computed cells
+	computedValues map[cellKey]any
+	// computedErr is the last computed value not matching its column type, see GetComputedError
+	computedErr error
+	// columnRenderers draw the cells of the columns instead of their text
+	columnRenderers map[int]CellRenderer
+
 	// filteredRows is the rows that are visible after filtering
 	filteredRows   [][]any
 	filteredColumn int
 	filterString   string
+	// valueFilters exact match filters per column index, see SetValueFilter
+	valueFilters map[int]map[string]struct{}
+	// nullFilters empty/not empty filters per column index, see SetNullFilter
+	nullFilters map[int]NullFilter
+
+	// nullDisplay is rendered in place of the null cells
+	nullDisplay string
+	// nullsOrder decides where the null cells are placed when sorting
+	nullsOrder NullsOrder
+
+	// facetPanel is the open facet panel, nil when closed
+	facetPanel  *facetPanel
+	facetLimit  int
+	facetKeyMap FacetKeyMap
+	// facets cache of the facets per column index, dropped when the rows or the filters change
+	facets map[int][]Facet
+	// paginated shows the rows page by page instead of scrolling them
+	paginated     bool
+	paginatorType PaginatorType
+	pageKeyMap    PageKeyMap
+	// dataSource provides the rows of the current page, page is the index of the loaded page
+	dataSource DataSource
+	page       int
+
+	// keyColumn identifies the rows when they are refreshed, -1 if not set
+	keyColumn int
+	// cellChanges cells changed by the last refreshes, highlighted until they expire
+	cellChanges       map[cellKey]cellChange
+	highlightDuration time.Duration
+	highlightTickID   int
+
+	// history recorded operations, historyIndex is the number of the operations that are applied
+	history       []historyEntry
+	historyIndex  int
+	historyLimit  int
+	historyHook   HistoryHook
+	historyKeyMap HistoryKeyMap
+
+	// statsPanelOpen shows the statistics of the column under the cursor over the rows
+	statsPanelOpen bool
+	statsKeyMap    StatsKeyMap
 
 	// orderColumnIndex notes which column is used for sorting
 	// -1 means that no column is sorted
@@ -83,6 +179,8 @@ type Table struct {
 	// orderedColumnPhase remarks if the sort is asc or desc, basically works like a toggle
 	// 0 indicates desc sorting, 1 indicates
 	orderedColumnPhase SortingOrderKey
+	// sortStack sorts applied to the rows, the last one is the active one
+	sortStack []sortEntry
 
 	// TODO: rename rowsTopIndex to follow columnVisibleLeftIndex format
 	// rowsTopIndex top visible index
@@ -90,6 +188,8 @@ type Table struct {
 	cursorIndexY    int
 	cursorIndexX    int
 	cursorDirection cursorDirection // not sure if needed
+	// cursorMode decides which part of the table the cursor highlights and how it moves
+	cursorMode CursorMode
 
 	// columnVisibleLeftIndex and columnVisibleRightIndex are used to calculate the columns on the screen
 	columnVisibleLeftIndex  int
@@ -105,10 +205,42 @@ type Table struct {
 	styles map[StyleKey]lipgloss.Style
 	// stylePassing if true, styles are passed all the way down from box to cell
 	stylePassing bool
+	// ellipsis is appended to the header and cell content that is truncated to fit the column
+	ellipsis string
 
 	headerBox *flexbox.FlexBox
 	rowsBox   *flexbox.FlexBox
 
+	// maxRows caps the number of rows, oldest rows are dropped first, 0 means no cap
+	maxRows int
+	// rowIDs ids given to the rows when they are added, keyed by the address of the array the table copied
+	// the row into, ids increase so they note the insertion order used to drop the oldest rows over the cap
+	// and to restore the order the rows were added in
+	rowIDs    map[*any]uint64
+	nextRowID uint64
+	// followTail keeps the cursor on the last row as rows are added
+	followTail bool
+
+	streamBatchSize     int
+	streamBatchInterval time.Duration
+
+	// originX and originY are the screen coordinates of the table, used to translate mouse events
+	originX int
+	originY int
+	// resizeColumnIndex is the column which header border is being dragged, -1 if none
+	resizeColumnIndex int
+	resizeStartX      int
+	resizeStartWidth  int
+	// resizePreviousWidth locked width of the column before the drag, restored by undo
+	resizePreviousWidth int
+	// resizeDragged reports whether the border moved since it was pressed
+	resizeDragged bool
+
+	// selectedRows rows selected for copying, keyed by rowID
+	selectedRows map[uint64]struct{}
+	// clipboardOutput is where OSC 52 sequences are written to, os.Stdout if nil
+	clipboardOutput io.Writer
+
 	// these flags indicate weather we should update rows and headers flex boxes
 	updateRowsFlag    bool
 	updateHeadersFlag bool
@@ -116,10 +248,14 @@ type Table struct {
 
 // NewTable initialize Table object with defaults
 func NewTable(width, height int, columnHeaders []string) *Table {
-	var columnRatio, columnMinWidth []int
-	for range columnHeaders {
+	var columnRatio, columnMinWidth, columnWidth, columnOrder []int
+	var columnHidden []bool
+	for i := range columnHeaders {
 		columnRatio = append(columnRatio, 1)
 		columnMinWidth = append(columnMinWidth, 0)
+		columnWidth = append(columnWidth, 0)
+		columnOrder = append(columnOrder, i)
+		columnHidden = append(columnHidden, false)
 	}
 
 	// by default all columns are of type string
@@ -135,9 +271,13 @@ func NewTable(width, height int, columnHeaders []string) *Table {
 		columnHeaders:           columnHeaders,
 		columnRatio:             columnRatio,
 		columnMinWidth:          columnMinWidth,
+		columnWidth:             columnWidth,
+		columnOrder:             columnOrder,
+		columnHidden:            columnHidden,
 		cursorIndexX:            0,
 		cursorIndexY:            0,
 		cursorDirection:         cursorDirectionUpLeft,
+		cursorMode:              CursorModeCell,
 		columnVisibleLeftIndex:  0,
 		columnVisibleRightIndex: 0,
 
@@ -148,6 +288,21 @@ func NewTable(width, height int, columnHeaders []string) *Table {
 		filteredColumn: -1,
 		filterString:   "",
 
+		nullDisplay: tableDefaultNullDisplay,
+		nullsOrder:  NullsLast,
+
+		facetLimit:  tableDefaultFacetLimit,
+		facetKeyMap: DefaultFacetKeyMap(),
+		pageKeyMap:  DefaultPageKeyMap(),
+
+		keyColumn:         -1,
+		highlightDuration: tableDefaultHighlightDuration,
+
+		historyLimit:  tableDefaultHistoryLimit,
+		historyKeyMap: DefaultHistoryKeyMap(),
+
+		statsKeyMap: DefaultStatsKeyMap(),
+
 		height: height,
 		width:  width,
 		// when optional header/footer is set rework this
@@ -161,34 +316,58 @@ func NewTable(width, height int, columnHeaders []string) *Table {
 
 		styles:       styles,
 		stylePassing: false,
+		ellipsis:     tableDefaultEllipsis,
+
+		streamBatchSize:     tableDefaultStreamBatchSize,
+		streamBatchInterval: tableDefaultStreamBatchInterval,
+
+		resizeColumnIndex: -1,
+
+		selectedRows: make(map[uint64]struct{}),
+		rowIDs:       make(map[*any]uint64),
 	}
+	r.updateColumnView()
 	r.recalculateVisibleColumnRange()
 	r.setHeadersUpdate()
 	return r
 }
 
 // SetRatio replaces the ratio slice, it has to be exactly the len of the headers/rows slices
-// also each value have to be greater than 0, if either fails we panic
-func (r *Table) SetRatio(values []int) *Table {
+// also each value have to be greater than 0, if either fails an error is returned and nothing changes
+func (r *Table) SetRatio(values []int) (*Table, error) {
 	if len(values) != len(r.columnHeaders) {
-		log.Fatalf("ratio list[%d] not of proper length[%d]\n", len(values), len(r.columnHeaders))
+		message := fmt.Sprintf("ratio list[%d] not of proper length[%d]", len(values), len(r.columnHeaders))
+		return r, ErrorColumnsLen{msg: message}
 	}
-	for _, val := range values {
+	for i, val := range values {
 		if val < 1 {
-			log.Fatalf("ratio value must be greater than 0")
+			message := fmt.Sprintf("ratio value[%d] on index %d must be greater than 0", val, i)
+			return r, ErrorBadValue{msg: message}
 		}
 	}
 	r.columnRatio = values
 	r.setHeadersUpdate()
 	r.setRowsUpdate()
+	return r, nil
+}
+
+// MustSetRatio executes SetRatio and panics if there is an error
+func (r *Table) MustSetRatio(values []int) *Table {
+	if _, err := r.SetRatio(values); err != nil {
+		panic(err)
+	}
 	return r
 }
 
 // SetTypes sets the column type, setting this will remove all the rows so make sure you do it when instantiating
-// Table object or add new rows after this, types have to be one of Ordered interface types
+// Table object or add new rows after this, types have to be one of Ordered interface types.
+// Computed columns have their type set when added, so they are not part of the list
 func (r *Table) SetTypes(columnTypes ...any) (*Table, error) {
-	if len(columnTypes) != len(r.columnHeaders) {
-		return r, errors.New("column types not the same len as headers")
+	if len(columnTypes) != len(r.columnType) {
+		message := fmt.Sprintf(
+			"column types list[%d] not the same len as headers[%d]", len(columnTypes), len(r.columnType),
+		)
+		return r, ErrorColumnsLen{msg: message}
 	}
 	for i, t := range columnTypes {
 		if !isOrdered(t) {
@@ -200,30 +379,63 @@ func (r *Table) SetTypes(columnTypes ...any) (*Table, error) {
 	}
 	r.cursorIndexY, r.cursorIndexX = 0, 0
 	r.rows = [][]any{}
+	r.ClearHistory()
+	r.selectedRows = make(map[uint64]struct{})
+	r.resetComputed()
+	r.resetFacets()
+	r.rowIDs = make(map[*any]uint64)
 	r.columnType = columnTypes
 	r.setRowsUpdate()
 	return r, nil
 }
 
+// MustSetTypes executes SetTypes and panics if there is an error
+func (r *Table) MustSetTypes(columnTypes ...any) *Table {
+	if _, err := r.SetTypes(columnTypes...); err != nil {
+		panic(err)
+	}
+	return r
+}
+
 // SetMinWidth replaces the minimum width slice, it has to be exactly the len of the headers/rows slices
-// if it's not matching len it will trigger fatal error
-func (r *Table) SetMinWidth(values []int) *Table {
+// and values can not be negative, if either fails an error is returned and nothing changes
+func (r *Table) SetMinWidth(values []int) (*Table, error) {
 	if len(values) != len(r.columnHeaders) {
-		log.Fatalf("min width list[%d] not of proper length[%d]\n", len(values), len(r.columnHeaders))
+		message := fmt.Sprintf("min width list[%d] not of proper length[%d]", len(values), len(r.columnHeaders))
+		return r, ErrorColumnsLen{msg: message}
+	}
+	for i, val := range values {
+		if val < 0 {
+			message := fmt.Sprintf("min width value[%d] on index %d can not be negative", val, i)
+			return r, ErrorBadValue{msg: message}
+		}
 	}
 	r.columnMinWidth = values
 	r.setHeadersUpdate()
 	r.setRowsUpdate()
+	return r, nil
+}
+
+// MustSetMinWidth executes SetMinWidth and panics if there is an error
+func (r *Table) MustSetMinWidth(values []int) *Table {
+	if _, err := r.SetMinWidth(values); err != nil {
+		panic(err)
+	}
 	return r
 }
 
 // SetHeight sets the height of the table including the header and footer
 func (r *Table) SetHeight(value int) *Table {
+	previousPageSize := r.pageSize()
 	r.height = value
 	// we deduct two to take header/footer into the account
 	r.rowsBoxHeight = value - 2
 	r.rowsBox.SetHeight(r.rowsBoxHeight)
 	r.setRowsUpdate()
+	// page size follows the height
+	if previousPageSize != r.pageSize() {
+		r.reloadPage(previousPageSize)
+	}
 	r.setTopRow()
 	return r
 }
@@ -237,7 +449,8 @@ func (r *Table) SetWidth(value int) *Table {
 	return r
 }
 
-// GetVisibleColumnRange returns the left and right visible column indexes
+// GetVisibleColumnRange returns the left and right visible column indexes,
+// these are display positions which differ from header indexes when columns are hidden or moved
 func (r *Table) GetVisibleColumnRange() (int, int) {
 	return r.columnVisibleLeftIndex, r.columnVisibleRightIndex
 }
@@ -265,23 +478,55 @@ func (r *Table) SetStylePassing(value bool) *Table {
 	return r
 }
 
-// UnsetFilter resets filtering
-func (r *Table) UnsetFilter() *Table {
-	r.filterString = ""
-	r.filteredColumn = -1
-	r.setTopRow()
+// SetEllipsis sets the string appended to the headers and cells that are truncated to fit the column
+// width, it is measured in terminal cells so it can be any width, use empty string to cut without a marker
+func (r *Table) SetEllipsis(value string) *Table {
+	r.ellipsis = value
 	r.setRowsUpdate()
 	r.setHeadersUpdate()
 	return r
 }
 
-// SetFilter sets filtering string on a column
-func (r *Table) SetFilter(columnIndex int, s string) *Table {
-	if columnIndex < len(r.columnHeaders) {
+// UnsetFilter resets filtering
+func (r *Table) UnsetFilter() *Table {
+	if r.filteredColumn < 0 && r.filterString == "" {
+		return r
+	}
+	// unset can not fail, hook can only revert it
+	_ = r.recordView("", r.setFilter(r.filteredColumn, r.filterString), r.setFilter(-1, ""))
+	return r
+}
+
+// SetFilter sets filtering string on a column, returns an error if the column does not exist
+func (r *Table) SetFilter(columnIndex int, s string) (*Table, error) {
+	if columnIndex < 0 || columnIndex >= len(r.columnHeaders) {
+		message := fmt.Sprintf("filter column index %d out of range[%d]", columnIndex, len(r.columnHeaders))
+		return r, ErrorIndexOutOfRange{msg: message}
+	}
+	if r.filterString == s && (s == "" || r.filteredColumn == columnIndex) {
+		// empty filter does not filter any column, moving it leaves the view as it was
+		r.setFilter(columnIndex, s)()
+		return r, nil
+	}
+	key := fmt.Sprintf("filter:%d", columnIndex)
+	return r, r.recordView(key, r.setFilter(r.filteredColumn, r.filterString), r.setFilter(columnIndex, s))
+}
+
+// setFilter returns the function setting the filtering string and column, used to do and undo the change
+func (r *Table) setFilter(columnIndex int, s string) func() {
+	return func() {
 		r.filterString = s
 		r.filteredColumn = columnIndex
-
+		r.setTopRow()
 		r.setRowsUpdate()
+		r.setHeadersUpdate()
+	}
+}
+
+// MustSetFilter executes SetFilter and panics if there is an error
+func (r *Table) MustSetFilter(columnIndex int, s string) *Table {
+	if _, err := r.SetFilter(columnIndex, s); err != nil {
+		panic(err)
 	}
 	return r
 }
@@ -292,30 +537,60 @@ func (r *Table) GetFilter() (columnIndex int, s string) {
 	return r.filteredColumn, r.filterString
 }
 
-// CursorDown move table cursor down
+// SetCursorMode sets the cursor mode, it changes both how the cursor is rendered and how
+// the Cursor* methods move, e.g. with CursorModeNone every movement only scrolls the table
+func (r *Table) SetCursorMode(mode CursorMode) *Table {
+	r.cursorMode = mode
+	r.setRowsUpdate()
+	return r
+}
+
+// GetCursorMode returns the current cursor mode
+func (r *Table) GetCursorMode() CursorMode {
+	return r.cursorMode
+}
+
+// CursorDown move table cursor down, scrolls the rows if the cursor mode does not track rows,
+// with a data source the cursor moves from the last row onto the first row of the next page
 func (r *Table) CursorDown() *Table {
+	if !r.cursorMode.tracksRow() {
+		return r.scrollRowsDown()
+	}
 	if r.cursorIndexY+1 < len(r.filteredRows) {
 		r.cursorDirection = r.cursorDirection.setDown()
 		r.cursorIndexY++
 		r.setTopRow()
 		r.setRowsUpdate()
+	} else if page, pages := r.GetPage(); r.dataSource != nil && page+1 < pages {
+		r.cursorDirection = r.cursorDirection.setDown()
+		r.crossPage(page+1, 0)
 	}
 	return r
 }
 
-// CursorUp move table cursor up
+// CursorUp move table cursor up, scrolls the rows if the cursor mode does not track rows,
+// with a data source the cursor moves from the first row onto the last row of the previous page
 func (r *Table) CursorUp() *Table {
+	if !r.cursorMode.tracksRow() {
+		return r.scrollRowsUp()
+	}
 	if r.cursorIndexY-1 > -1 {
 		r.cursorDirection = r.cursorDirection.setUp()
 		r.cursorIndexY--
 		r.setTopRow()
 		r.setRowsUpdate()
+	} else if page, _ := r.GetPage(); r.dataSource != nil && page > 0 {
+		r.cursorDirection = r.cursorDirection.setUp()
+		r.crossPage(page-1, r.pageSize()-1)
 	}
 	return r
 }
 
-// CursorLeft move table cursor left
+// CursorLeft move table cursor left, scrolls the columns if the cursor mode does not track columns
 func (r *Table) CursorLeft() *Table {
+	if !r.cursorMode.tracksColumn() {
+		return r.scrollColumnsLeft()
+	}
 	if r.cursorIndexX-1 > -1 {
 		r.cursorDirection = r.cursorDirection.setLeft()
 		r.cursorIndexX--
@@ -326,9 +601,12 @@ func (r *Table) CursorLeft() *Table {
 	return r
 }
 
-// CursorRight move table cursor right
+// CursorRight move table cursor right, scrolls the columns if the cursor mode does not track columns
 func (r *Table) CursorRight() *Table {
-	if r.cursorIndexX+1 < len(r.columnHeaders) {
+	if !r.cursorMode.tracksColumn() {
+		return r.scrollColumnsRight()
+	}
+	if r.cursorIndexX+1 < len(r.columnView) {
 		r.cursorDirection = r.cursorDirection.setRight()
 		r.cursorIndexX++
 		// TODO: update row only
@@ -338,18 +616,21 @@ func (r *Table) CursorRight() *Table {
 	return r
 }
 
-// GetCursorLocation returns the current x,y position of the cursor
+// GetCursorLocation returns the current x,y position of the cursor, x is the index of the column
+// under the cursor as in the headers, so it can be passed to methods like OrderByAsc or SetFilter,
+// it is -1 when all the columns are hidden
 func (r *Table) GetCursorLocation() (int, int) {
-	return r.cursorIndexX, r.cursorIndexY
+	return r.cursorColumn(), r.cursorIndexY
 }
 
-// GetCursorValue returns the string of the cell under the cursor
+// GetCursorValue returns the string of the cell under the cursor, an empty string is returned when
+// the cursor mode does not track both the row and the column, as there is no single cell under the cursor
 func (r *Table) GetCursorValue() string {
-	// handle 0 rows situation and when table is not active
-	if len(r.filteredRows) == 0 || r.cursorIndexX < 0 || r.cursorIndexY < 0 {
+	// handle 0 rows situation and when there is no cell to read from
+	if len(r.filteredRows) == 0 || !r.cursorMode.tracksRow() || !r.cursorMode.tracksColumn() || r.cursorColumn() < 0 {
 		return ""
 	}
-	return getStringFromOrdered(r.filteredRows[r.cursorIndexY][r.cursorIndexX])
+	return getStringFromOrdered(r.cellValue(r.filteredRows[r.cursorIndexY], r.cursorColumn()))
 }
 
 // AddRows add multiple rows, will return error on the first instance of a row that does not match the type set on table
@@ -362,10 +643,7 @@ func (r *Table) AddRows(rows [][]any) (*Table, error) {
 		}
 	}
 	// append rows
-	r.rows = append(r.rows, rows...)
-
-	r.applyFilter()
-	r.setRowsUpdate()
+	r.appendRows(rows)
 	return r, nil
 }
 
@@ -377,13 +655,78 @@ func (r *Table) MustAddRows(rows [][]any) *Table {
 	return r
 }
 
-// ClearRows removes all previously added rows, can be used as part of an update loop
+// ClearRows removes all previously added rows, can be used as part of an update loop, it clears the history
 func (r *Table) ClearRows() *Table {
 	r.rows = make([][]any, 0, 10)
+	r.ClearHistory()
+	r.selectedRows = make(map[uint64]struct{})
+	r.resetComputed()
+	r.resetFacets()
+	r.rowIDs = make(map[*any]uint64)
 	r.setRowsUpdate()
 	return r
 }
 
+// Update handles the messages table subscribes to, StreamMsg from Stream, HighlightTickMsg from RefreshRows
+// and tea.MouseMsg,
+// while the facet panel is open tea.KeyMsg and tea.MouseMsg are handled by the panel,
+// otherwise tea.KeyMsg undoes and redoes operations, toggles the statistics panel and switches pages while paginated.
+// Returns the command that has to be passed back to the bubbletea runtime
+func (r *Table) Update(msg tea.Msg) (*Table, tea.Cmd) {
+	switch msg := msg.(type) {
+	case StreamMsg:
+		if msg.table != r {
+			return r, nil
+		}
+		return r, r.handleStream(msg)
+	case HighlightTickMsg:
+		if msg.table != r {
+			return r, nil
+		}
+		return r, r.handleHighlightTick(msg)
+	case tea.KeyMsg:
+		if r.facetPanel != nil {
+			r.handleFacetKey(msg)
+			return r, nil
+		}
+		if key := msg.String(); keyMatches(key, r.historyKeyMap.Undo) || keyMatches(key, r.historyKeyMap.Redo) {
+			return r, r.handleHistoryKey(msg)
+		}
+		if keyMatches(msg.String(), r.statsKeyMap.Toggle) {
+			r.ToggleStatsPanel()
+			return r, nil
+		}
+		if r.paginated {
+			return r, r.handlePageKey(msg)
+		}
+	case tea.MouseMsg:
+		if r.facetPanel != nil {
+			r.handleFacetMouse(msg)
+			return r, nil
+		}
+		return r, r.handleMouse(msg)
+	}
+	return r, nil
+}
+
+// RenderSized sets the size of the table and renders it, it implements flexbox.Renderable
+// so the table can be nested in a flexbox.Cell with SetRenderable
+func (r *Table) RenderSized(width, height int) string {
+	if width != r.width {
+		r.SetWidth(width)
+	}
+	if height != r.height {
+		r.SetHeight(height)
+	}
+	return r.Render()
+}
+
+// PreferredSize returns the given size, the table always fills the size it is rendered into,
+// it implements flexbox.PreferredSizer so measuring the table does not resize it
+func (r *Table) PreferredSize(width, height int) (int, int) {
+	return width, height
+}
+
 // Render renders the table into the string
 func (r *Table) Render() string {
 	r.updateRows()
@@ -391,19 +734,29 @@ func (r *Table) Render() string {
 
 	statusMessage := fmt.Sprintf(
 		"%d:%d / %d:%d ",
-		r.cursorIndexX,
+		r.cursorColumn(),
 		r.cursorIndexY,
 		r.rowsBox.GetWidth(),
 		r.rowsBox.GetHeight(),
 	)
-	if r.cursorIndexX == r.filteredColumn {
+	if r.cursorColumn() == r.filteredColumn {
 		statusMessage = fmt.Sprintf("filtered by: %q / %s", r.filterString, statusMessage)
 	}
+	if r.paginated {
+		statusMessage = fmt.Sprintf("%s  %s", r.renderPaginator(), statusMessage)
+	}
+
+	rows := r.rowsBox.Render()
+	if r.facetPanel != nil {
+		rows = r.renderFacetPanel()
+	} else if r.statsPanelOpen {
+		rows = r.renderStatsPanel()
+	}
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		r.headerBox.Render(),
-		r.rowsBox.Render(),
+		rows,
 		r.styles[StyleKeyFooter].Width(r.width).Render(statusMessage),
 	)
 }
@@ -425,7 +778,7 @@ func (r *Table) unsetHeadersUpdate() {
 }
 
 // validateRow checks the row for validity, number of cells must match table header length
-// and header types per cell as well
+// and header types per cell as well, nil cells hold no value and are valid in any column
 func (r *Table) validateRow(cells ...any) error {
 	var message string
 	// check row len
@@ -437,22 +790,36 @@ func (r *Table) validateRow(cells ...any) error {
 	}
 	// check cell type
 	for i, c := range cells {
-		switch c.(type) {
-		case string, int, int8, int16, int32, float32, float64:
-			// check if the cell matches the type of the column
-			if reflect.TypeOf(c) != reflect.TypeOf(r.columnType[i]) {
-				message = fmt.Sprintf(
-					"type of the cell[%v] on index %d not matching type of the column[%v]",
-					reflect.TypeOf(c), i, reflect.TypeOf(r.columnType[i]),
-				)
-				return ErrorBadCellType{msg: message}
-			}
-		default:
-			message = fmt.Sprintf(
-				"type[%v] on index %d not matching Ordered interface types", reflect.TypeOf(c), i,
+		if err := r.validateCell(r.columnRenderers[i], c, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCell checks the cell of the column with index n, cells of the column drawn by the renderer
+// are validated by it, nil renderer checks the type of the column
+func (r *Table) validateCell(renderer CellRenderer, c any, index int) error {
+	if renderer != nil && c != nil {
+		return r.validateRenderedCell(renderer, c, index)
+	}
+	switch c.(type) {
+	case nil:
+		return nil
+	case string, int, int8, int16, int32, float32, float64:
+		// check if the cell matches the type of the column
+		if reflect.TypeOf(c) != reflect.TypeOf(r.columnType[index]) {
+			message := fmt.Sprintf(
+				"type of the cell[%v] on index %d not matching type of the column[%v]",
+				reflect.TypeOf(c), index, reflect.TypeOf(r.columnType[index]),
 			)
-			return ErrorBadType{msg: message}
+			return ErrorBadCellType{msg: message}
 		}
+	default:
+		message := fmt.Sprintf(
+			"type[%v] on index %d not matching Ordered interface types", reflect.TypeOf(c), index,
+		)
+		return ErrorBadType{msg: message}
 	}
 	return nil
 }
@@ -468,13 +835,15 @@ func (r *Table) updateHeader() *Table {
 	leftmostColumnIndex, rightmostColumnIndex := r.columnVisibleLeftIndex, r.columnVisibleRightIndex
 	if r.width == 0 {
 		// this is the case when we initialize the table and width is not set yet
-		rightmostColumnIndex = len(r.columnHeaders) - 1
+		rightmostColumnIndex = len(r.columnView) - 1
 	}
-	for index := leftmostColumnIndex; index <= rightmostColumnIndex; index++ {
-		title := r.columnHeaders[index]
+	for position := leftmostColumnIndex; position <= rightmostColumnIndex; position++ {
+		index := r.columnView[position]
+		header := r.columnHeaders[index]
 		cells = append(
 			cells,
-			flexbox.NewCell(r.columnRatio[index], 1).SetMinWidth(r.columnMinWidth[index]).SetContentGenerator(func(maxX, maxY int) string {
+			flexbox.NewCell(r.columnRatio[index], 1).SetMinWidth(r.columnMinWidth[index]).SetFixedWidth(r.columnWidth[index]).SetContentGenerator(func(maxX, maxY int) string {
+				title := header
 				// titleSuffix at the moment can be sort and filter characters
 				// filtering symbol should be visible always, if possible of course, and as far right as possible
 				// there should be a minimum of space bar between two symbols and symbol and row to the right
@@ -489,27 +858,27 @@ func (r *Table) updateHeader() *Table {
 				}
 
 				// add filtering symbol if the filtering is active on the column
-				if r.filteredColumn == index && r.filterString != "" {
+				if (r.filteredColumn == index && r.filterString != "") || len(r.valueFilters[index]) > 0 || r.nullFilters[index] != NullFilterNone {
 					// add at least one space bar between char to the left, and one to the right
 					titleSuffix = titleSuffix + strings.Repeat(
 						" ", int(math.Max(
 							1,
 							float64(
-								maxX-utf8.RuneCountInString(title+titleSuffix)-2,
+								maxX-ansi.StringWidth(title+titleSuffix)-2,
 							),
 						)),
 					) + tableDefaultFilterChar + " "
 				}
 
 				// if title and suffix exceed width trim the title
-				if maxX-utf8.RuneCountInString(title+titleSuffix) < 0 {
+				if maxX-ansi.StringWidth(title+titleSuffix) < 0 {
 					// this will be the cae only when sort is on and filter is off
 					// add one space bar between sort and column to the right
-					if utf8.RuneCountInString(titleSuffix) == 2 {
+					if ansi.StringWidth(titleSuffix) == 2 {
 						titleSuffix = titleSuffix + " "
 					}
-					// trim the title
-					title = title[0:int(math.Max(0, float64(maxX-utf8.RuneCountInString(titleSuffix))))]
+					// trim the title, widths are in terminal cells so wide runes and styled titles are cut safely
+					title = ansi.Truncate(title, int(math.Max(0, float64(maxX-ansi.StringWidth(titleSuffix)))), r.ellipsis)
 				}
 				return title + titleSuffix
 			}),
@@ -535,7 +904,7 @@ func (r *Table) updateRows() {
 		r.unsetRowsUpdate()
 		return
 	}
-	r.applyFilter()
+	r.filterRows()
 
 	// calculate the bottom most visible row index
 	rowsBottomIndex := r.rowsTopIndex + r.rowsBoxHeight
@@ -549,15 +918,36 @@ func (r *Table) updateRows() {
 		irCorrected := ir + r.rowsTopIndex
 
 		var cells []*flexbox.Cell
-		for ic, column := range columns[r.columnVisibleLeftIndex : r.columnVisibleRightIndex+1] {
-			icCorrected := ic + r.columnVisibleLeftIndex
+		for icCorrected := r.columnVisibleLeftIndex; icCorrected <= r.columnVisibleRightIndex; icCorrected++ {
+			// icCorrected is the display position, index is the column index as in the headers
+			index := r.columnView[icCorrected]
 			// initialize column cell
-			c := flexbox.NewCell(r.columnRatio[ic], r.rowHeight).
-				SetMinWidth(r.columnMinWidth[ic]).
-				SetContent(getStringFromOrdered(column))
-			// update style if cursor is on the cell, otherwise it's inherited from the row
-			if irCorrected == r.cursorIndexY && icCorrected == r.cursorIndexX {
-				c.SetStyle(r.styles[StyleKeyCellCursor])
+			content := r.displayValue(columns, index)
+			renderer, rendered := r.columnRenderers[index]
+			value := r.rawCellValue(columns, index)
+			c := flexbox.NewCell(r.columnRatio[index], r.rowHeight).
+				SetMinWidth(r.columnMinWidth[index]).
+				SetFixedWidth(r.columnWidth[index]).
+				SetContentGenerator(func(maxX, maxY int) string {
+					if rendered && value != nil {
+						return ansi.Truncate(renderer.Render(value, maxX, maxY), maxX, r.ellipsis)
+					}
+					return ansi.Truncate(content, maxX, r.ellipsis)
+				})
+			// changed cells are highlighted unless the cursor is on them
+			if style, ok := r.cellChangeStyle(columns, index); ok {
+				c.SetStyle(r.styles[style])
+			}
+			// update style if cursor is on the cell or column, otherwise it's inherited from the row
+			switch r.cursorMode {
+			case CursorModeCell:
+				if irCorrected == r.cursorIndexY && icCorrected == r.cursorIndexX {
+					c.SetStyle(r.styles[StyleKeyCellCursor])
+				}
+			case CursorModeColumn:
+				if icCorrected == r.cursorIndexX {
+					c.SetStyle(r.styles[StyleKeyColumnCursor])
+				}
 			}
 			cells = append(cells, c)
 		}
@@ -567,8 +957,10 @@ func (r *Table) updateRows() {
 		// rows have three styles, normal, subsequent and selected
 		// normal and subsequent rows should differ for readability
 		// TODO: make this ^ optional
-		if irCorrected == r.cursorIndexY {
+		if irCorrected == r.cursorIndexY && r.cursorMode.tracksRow() {
 			rw.SetStyle(r.styles[StyleKeyRowsCursor])
+		} else if r.isRowSelected(columns) {
+			rw.SetStyle(r.styles[StyleKeyRowsSelected])
 		} else if irCorrected%2 == 0 || irCorrected == 0 {
 			rw.SetStyle(r.styles[StyleKeyRowsSubsequent])
 		} else {
@@ -584,19 +976,22 @@ func (r *Table) updateRows() {
 	r.unsetRowsUpdate()
 }
 
-// applyFilter filters column n by a value s
+// applyFilter filters the rows after the rows or the filters changed
 func (r *Table) applyFilter() *Table {
+	r.resetFacets()
+	return r.filterRows()
+}
+
+// filterRows filters column n by a value s, and the columns with value filters by their values
+func (r *Table) filterRows() *Table {
 	// sending empty string should reset the filtering
-	if r.filterString == "" {
+	if r.filterString == "" && len(r.valueFilters) == 0 && len(r.nullFilters) == 0 {
 		r.filteredRows = r.rows
 		return r
 	}
 	var filteredRows [][]any
 	for _, row := range r.rows {
-		cellValue := getStringFromOrdered(row[r.filteredColumn])
-		// convert to lower, not sure if anybody needs case-sensitive filtering
-		// if you are reading this and need it, open up an issue :zap:
-		if strings.Contains(strings.ToLower(cellValue), strings.ToLower(r.filterString)) {
+		if r.rowMatches(row, -1) {
 			filteredRows = append(filteredRows, row)
 		}
 	}
@@ -606,6 +1001,50 @@ func (r *Table) applyFilter() *Table {
 	return r
 }
 
+// scrollRowsDown shifts visible rows down by one, used when the cursor does not track rows,
+// the hidden cursor is parked just below the visible rows so setTopRow follows it
+func (r *Table) scrollRowsDown() *Table {
+	rowsBottomIndex := r.rowsTopIndex + r.rowsBoxHeight
+	if rowsBottomIndex < len(r.filteredRows) {
+		r.cursorIndexY = rowsBottomIndex
+		r.setTopRow()
+		r.setRowsUpdate()
+	}
+	return r
+}
+
+// scrollRowsUp shifts visible rows up by one, used when the cursor does not track rows
+func (r *Table) scrollRowsUp() *Table {
+	if r.rowsTopIndex > 0 {
+		r.cursorIndexY = r.rowsTopIndex - 1
+		r.setTopRow()
+		r.setRowsUpdate()
+	}
+	return r
+}
+
+// scrollColumnsRight shifts visible columns right by one, used when the cursor does not track columns
+func (r *Table) scrollColumnsRight() *Table {
+	if r.columnVisibleRightIndex+1 < len(r.columnView) {
+		r.cursorDirection = r.cursorDirection.setRight()
+		r.cursorIndexX = r.columnVisibleRightIndex + 1
+		r.setRowsUpdate()
+		r.checkVisibleColumnRange()
+	}
+	return r
+}
+
+// scrollColumnsLeft shifts visible columns left by one, used when the cursor does not track columns
+func (r *Table) scrollColumnsLeft() *Table {
+	if r.columnVisibleLeftIndex > 0 {
+		r.cursorDirection = r.cursorDirection.setLeft()
+		r.cursorIndexX = r.columnVisibleLeftIndex - 1
+		r.setRowsUpdate()
+		r.checkVisibleColumnRange()
+	}
+	return r
+}
+
 // setTopRow calculates the row top index used when deciding what is visible
 func (r *Table) setTopRow() {
 	// if rows are empty set y to 0, retain x pos
@@ -618,6 +1057,18 @@ func (r *Table) setTopRow() {
 		r.cursorIndexY = len(r.filteredRows) - 1
 	}
 
+	// paginated rows start at the page the cursor is on, data source holds the current page only
+	if r.paginated {
+		if r.cursorIndexY >= len(r.filteredRows) && len(r.filteredRows) != 0 {
+			r.cursorIndexY = len(r.filteredRows) - 1
+		}
+		r.rowsTopIndex = 0
+		if r.dataSource == nil {
+			r.rowsTopIndex = r.cursorIndexY / r.pageSize() * r.pageSize()
+		}
+		return
+	}
+
 	// case when cursor is in between top or bottom visible row
 	if r.cursorIndexY >= r.rowsTopIndex && r.cursorIndexY < r.rowsTopIndex+r.rowsBoxHeight {
 		// if cursor is on the last item in row, adjust the row top
@@ -672,11 +1123,11 @@ func (r *Table) recalculateVisibleColumnRange() {
 }
 
 func (r *Table) columnIndexSeekLeft(index int, widthAdded int) (int, int) {
-	for i := index; i >= 0; i-- {
-		if widthAdded+r.columnMinWidth[i] > r.width {
+	for i := index; i >= 0 && i < len(r.columnView); i-- {
+		if widthAdded+r.columnSeekWidth(i) > r.width {
 			return i + 1, widthAdded
 		}
-		widthAdded += r.columnMinWidth[i]
+		widthAdded += r.columnSeekWidth(i)
 		if widthAdded == r.width || i == 0 {
 			return i, widthAdded
 		}
@@ -685,16 +1136,26 @@ func (r *Table) columnIndexSeekLeft(index int, widthAdded int) (int, int) {
 }
 
 func (r *Table) columnIndexSeekRight(index int, widthAdded int) (int, int) {
-	for i := index; i < len(r.columnHeaders); i++ {
-		if widthAdded+r.columnMinWidth[i] > r.width {
+	for i := index; i >= 0 && i < len(r.columnView); i++ {
+		if widthAdded+r.columnSeekWidth(i) > r.width {
 			return i - 1, widthAdded
 		}
-		widthAdded += r.columnMinWidth[i]
-		if widthAdded == r.width || i == len(r.columnHeaders)-1 {
+		widthAdded += r.columnSeekWidth(i)
+		if widthAdded == r.width || i == len(r.columnView)-1 {
 			return i, widthAdded
 		}
 	}
-	return len(r.columnHeaders) - 1, widthAdded
+	return len(r.columnView) - 1, widthAdded
+}
+
+// columnSeekWidth returns the width column on the display position takes at least,
+// locked width if set otherwise min width
+func (r *Table) columnSeekWidth(position int) int {
+	index := r.columnView[position]
+	if r.columnWidth[index] > 0 {
+		return r.columnWidth[index]
+	}
+	return r.columnMinWidth[index]
 }
 
 // checkVisibleColumnRange should be executed only after the cursor is moved left or right